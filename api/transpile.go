@@ -4,19 +4,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/parser"
-	"go/token"
 	"net/http"
 	"go2kotlin/pkg/transpiler"
 )
 
 // Estruturas de Dados (DTOs)
 type RequestBody struct {
-	GoCode string `json:"code"`
+	GoCode  string              `json:"code"`
+	Options transpiler.Options `json:"options"`
 }
 
 type ResponseBody struct {
-	KotlinCode string `json:"kotlin"`
-	Error      string `json:"error,omitempty"`
+	KotlinCode string   `json:"kotlin"`
+	Error      string   `json:"error,omitempty"`
+	Warnings   []string `json:"warnings,omitempty"`
+
+	// Arquivos de suporte que acompanham o código gerado (ex: GoFormat.kt)
+	SupportFiles map[string]string `json:"supportFiles,omitempty"`
 }
 
 // Handler é a função exportada que a Vercel executa
@@ -46,8 +50,8 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Executa a lógica do transpilador
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, "editor.go", req.GoCode, parser.ParseComments)
+	tr := transpiler.NewTranspilerWithOptions(req.Options)
+	node, err := parser.ParseFile(tr.FileSet(), "editor.go", req.GoCode, parser.ParseComments)
 	
 	response := ResponseBody{}
 
@@ -55,11 +59,14 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		response.Error = fmt.Sprintf("Erro de Sintaxe Go: %v", err)
 	} else {
 		// Chama o Transpilador do seu pacote pkg
-		tr := transpiler.NewTranspiler()
 		if err := tr.Transpile(node); err != nil {
 			response.Error = fmt.Sprintf("Erro na Conversão: %v", err)
 		} else {
 			response.KotlinCode = tr.GetOutput()
+			response.SupportFiles = tr.SupportFiles()
+			for _, d := range tr.Diagnostics() {
+				response.Warnings = append(response.Warnings, d.String())
+			}
 		}
 	}
 
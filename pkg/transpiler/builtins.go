@@ -0,0 +1,260 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// builtinName devolve o nome da função builtin chamada, ou "" se a chamada
+// não for a uma builtin. Sem informação de tipos, confia apenas no nome.
+func (t *Transpiler) builtinName(call *ast.CallExpr) string {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	if t.info == nil {
+		return id.Name
+	}
+	if _, isBuiltin := t.objectOf(id).(*types.Builtin); isBuiltin {
+		return id.Name
+	}
+	return ""
+}
+
+// transpileBuiltin traduz as funções builtin do Go para os equivalentes do
+// Kotlin. Devolve false se a chamada não for uma builtin tratada aqui.
+func (t *Transpiler) transpileBuiltin(call *ast.CallExpr) bool {
+	name := t.builtinName(call)
+	if name == "" {
+		return false
+	}
+	// len/cap de arrays e strings constantes, min/max de constantes
+	if t.info != nil {
+		if tv, ok := t.info.Types[call]; ok && tv.Value != nil {
+			if literal, ok := t.constLiteral(tv.Value, tv.Type); ok {
+				t.write(literal)
+				return true
+			}
+		}
+	}
+	switch name {
+	case "make":
+		return t.transpileMake(call)
+	case "append":
+		return t.transpileAppend(call)
+	case "len", "cap":
+		return len(call.Args) == 1 && t.transpileLen(call, name)
+	case "delete":
+		if len(call.Args) != 2 {
+			return false
+		}
+		t.writeSafeCall(call.Args[0], "remove")
+		t.Transpile(call.Args[1])
+		t.write(")")
+	case "close":
+		if len(call.Args) != 1 {
+			return false
+		}
+		t.Transpile(call.Args[0])
+		t.writeNonNull(call.Args[0])
+		t.write(".close()")
+	case "clear":
+		if len(call.Args) != 1 {
+			return false
+		}
+		return t.transpileClear(call.Args[0])
+	case "min", "max":
+		t.write(name + "Of")
+		t.writeElements(call.Args)
+	case "real", "imag", "complex":
+		return t.transpileComplexBuiltin(call, name)
+	case "panic":
+		// panic lança GoPanic, declarada no runtime
+		t.useRuntime("panic")
+		t.write("panic")
+		t.writeCallArgs(call)
+	case "recover":
+		t.useRuntime("recover")
+		t.write("recover()")
+	case "print", "println":
+		// As builtins escrevem em stderr; println separa os argumentos com espaço
+		t.write("System.err." + name + "(")
+		spaced := neverSpaced
+		if name == "println" {
+			spaced = alwaysSpaced
+		}
+		t.writeTemplate(call.Args, spaced, "")
+		t.write(")")
+	default:
+		return false
+	}
+	return true
+}
+
+// transpileMake traduz make: slices viram listas preenchidas com o valor zero
+// (a capacidade não existe no Kotlin), maps viram mutableMapOf e channels
+// com buffer recebem a capacidade
+func (t *Transpiler) transpileMake(call *ast.CallExpr) bool {
+	if len(call.Args) == 0 {
+		return false
+	}
+	typ := t.typeOf(call.Args[0])
+	if typ == nil {
+		switch arg := call.Args[0].(type) {
+		case *ast.MapType:
+			t.write("mutableMapOf<" + t.resolveType(arg.Key) + ", " + t.resolveType(arg.Value) + ">()")
+			return true
+		case *ast.ChanType:
+			t.write("Channel<" + t.resolveType(arg.Value) + ">()")
+			return true
+		}
+		return false
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Slice:
+		if len(call.Args) < 2 {
+			return false
+		}
+		t.write("MutableList(")
+		t.writeIntOperand(call.Args[1], "Int", false)
+		t.write(") { " + t.zeroValue(u.Elem()) + " }")
+	case *types.Map:
+		t.write("mutableMapOf<" + t.resolveGoType(u.Key()) + ", " + t.resolveGoType(u.Elem()) + ">()")
+	case *types.Chan:
+		t.write("Channel<" + t.resolveGoType(u.Elem()) + ">(")
+		if len(call.Args) > 1 {
+			t.writeIntOperand(call.Args[1], "Int", false)
+		}
+		t.write(")")
+	default:
+		return false
+	}
+	return true
+}
+
+// transpileLen traduz len e cap pelo tipo do operando: .length para strings
+// (ou o tamanho em bytes UTF-8, com a opção Utf8Len) e .size para coleções.
+// Maps nil têm tamanho zero.
+func (t *Transpiler) transpileLen(call *ast.CallExpr, name string) bool {
+	arg := call.Args[0]
+	typ := t.typeOf(arg)
+	if typ == nil {
+		t.transpileOperand(arg)
+		t.write(".size")
+		return true
+	}
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		if u.Info()&types.IsString == 0 {
+			return false
+		}
+		t.transpileUnwrapped(arg, typ)
+		if t.options.Utf8Len {
+			t.write(".toByteArray().size")
+		} else {
+			t.write(".length")
+		}
+	case *types.Slice, *types.Array:
+		if _, isSlice := u.(*types.Slice); isSlice && name == "cap" {
+			// MutableList não expõe a capacidade reservada
+			t.diagnose(call.Pos(), "cap de slice não tem equivalente no Kotlin; usando o tamanho")
+		}
+		t.transpileOperand(arg)
+		t.writeNonNull(arg)
+		t.write(".size")
+	case *types.Map:
+		if t.mayBeNull(arg) {
+			t.write("(")
+			t.transpileOperand(arg)
+			t.write("?.size ?: 0)")
+			return true
+		}
+		t.transpileOperand(arg)
+		t.write(".size")
+	case *types.Chan:
+		// Channel do Kotlin não expõe quantos elementos estão no buffer
+		t.diagnose(call.Pos(), name+" de channel não tem equivalente no Kotlin; usando 0")
+		t.write("0 /* " + name + "(chan) */")
+	default:
+		return false
+	}
+	return true
+}
+
+// transpileClear traduz clear: maps são esvaziados e slices têm os
+// elementos trocados pelo valor zero, mantendo o tamanho
+func (t *Transpiler) transpileClear(arg ast.Expr) bool {
+	typ := t.typeOf(arg)
+	if typ == nil {
+		t.transpileOperand(arg)
+		t.write(".clear()")
+		return true
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Map:
+		t.writeSafeCall(arg, "clear")
+		t.write(")")
+	case *types.Slice:
+		t.transpileOperand(arg)
+		t.write(".fill(" + t.zeroValue(u.Elem()) + ")")
+	default:
+		return false
+	}
+	return true
+}
+
+// writeSafeCall escreve o início da chamada de um método, usando ?. quando o
+// receptor pode ser nil (ex: delete em um map nil não faz nada no Go)
+func (t *Transpiler) writeSafeCall(recv ast.Expr, method string) {
+	t.transpileOperand(recv)
+	if t.mayBeNull(recv) {
+		t.write("?.")
+	} else {
+		t.write(".")
+	}
+	t.write(method + "(")
+}
+
+// writeTemplate escreve os argumentos como uma string template do Kotlin,
+// separados por espaço quando spaced aprova o par e seguidos de suffix
+func (t *Transpiler) writeTemplate(args []ast.Expr, spaced func(prev, next ast.Expr) bool, suffix string) {
+	if len(args) == 1 && suffix == "" && t.needsGoFormat(args[0]) {
+		if _, constant := t.constText(args[0]); !constant {
+			t.writeGoFormat(args[0], "")
+			return
+		}
+	}
+	if len(args) == 1 && suffix == "" {
+		if typ := t.typeOf(args[0]); typ != nil && t.valueClassOf(typ) == nil {
+			if b, ok := typ.Underlying().(*types.Basic); ok && b.Info()&types.IsString != 0 {
+				t.Transpile(args[0])
+				return
+			}
+		}
+	}
+	t.write(`"`)
+	for i, arg := range args {
+		if i > 0 && spaced(args[i-1], arg) {
+			t.write(" ")
+		}
+		if text, ok := t.constText(arg); ok {
+			t.write(escapeKotlinText(text))
+			continue
+		}
+		t.write("${")
+		if t.needsGoFormat(arg) {
+			t.writeGoFormat(arg, "")
+		} else if t.fmtKind(arg) == "char" {
+			// Runas são impressas pelo código, como números
+			t.transpileOperand(arg)
+			t.write(".code")
+		} else {
+			t.Transpile(arg)
+		}
+		t.write("}")
+	}
+	t.write(suffix + `"`)
+}
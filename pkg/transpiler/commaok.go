@@ -0,0 +1,181 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// transpileCommaOk traduz as formas de dois valores v, ok := m[k],
+// v, ok := x.(T) e v, ok := <-ch. O ok é calculado antes do valor, para que
+// reatribuir o operando (ex: x, ok = x.(T)) não altere o teste.
+func (t *Transpiler) transpileCommaOk(n *ast.AssignStmt) bool {
+	if t.info == nil || len(n.Lhs) != 2 || len(n.Rhs) != 1 {
+		return false
+	}
+	define := n.Tok == token.DEFINE
+	switch e := ast.Unparen(n.Rhs[0]).(type) {
+	case *ast.IndexExpr:
+		if !t.isMapIndex(e) {
+			return false
+		}
+		t.writeCommaOk(n.Lhs, define, func() {
+			t.Transpile(e)
+		}, func() {
+			t.writeSafeCall(e.X, "containsKey")
+			t.Transpile(e.Index)
+			t.write(")")
+			if t.mayBeNull(e.X) {
+				t.write(" == true")
+			}
+		})
+	case *ast.TypeAssertExpr:
+		typ := t.typeOf(e.Type)
+		if typ == nil {
+			return false
+		}
+		ktType := strings.TrimSuffix(t.resolveGoType(typ), "?")
+		t.writeCommaOk(n.Lhs, define, func() {
+			t.transpileOperand(e.X)
+			t.write(" as? " + ktType)
+			if !nullableZero(typ) {
+				t.write(" ?: " + t.zeroValue(typ))
+			}
+		}, func() {
+			t.transpileOperand(e.X)
+			t.write(" is " + erasedType(ktType))
+		})
+	case *ast.UnaryExpr:
+		if e.Op != token.ARROW {
+			return false
+		}
+		typ := t.typeOf(e.X)
+		if typ == nil {
+			return false
+		}
+		ch, isChan := typ.Underlying().(*types.Chan)
+		if !isChan {
+			return false
+		}
+		elem := ch.Elem()
+		// receiveCatching não lança exceção quando o channel está fechado
+		result := t.newTemp()
+		t.write("val " + result + " = ")
+		t.Transpile(e.X)
+		t.writeNonNull(e.X)
+		t.write(".receiveCatching()")
+		t.write("\n")
+		t.writeIndent()
+		t.writeCommaOk(n.Lhs, define, func() {
+			t.write(result + ".getOrNull()")
+			if !nullableZero(elem) {
+				t.write(" ?: " + t.zeroValue(elem))
+			}
+		}, func() {
+			t.write(result + ".isSuccess")
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+// writeCommaOk escreve as atribuições de ok e do valor, uma por linha,
+// ignorando o identificador vazio (_)
+func (t *Transpiler) writeCommaOk(lhs []ast.Expr, define bool, value, ok func()) {
+	first := true
+	for _, part := range []struct {
+		target ast.Expr
+		write  func()
+	}{{lhs[1], ok}, {lhs[0], value}} {
+		if id, isIdent := part.target.(*ast.Ident); isIdent && id.Name == "_" {
+			continue
+		}
+		if !first {
+			t.write("\n")
+			t.writeIndent()
+		}
+		first = false
+		if id, isIdent := part.target.(*ast.Ident); isIdent && t.isNewVar(id, define) {
+			t.write(t.declKeyword(id) + " ")
+		}
+		t.transpileTarget(part.target)
+		t.write(" = ")
+		part.write()
+	}
+}
+
+// isNewVar informa se o identificador à esquerda de := declara uma variável
+// nova (em a, err := f(), err pode já existir e ser apenas reatribuída)
+func (t *Transpiler) isNewVar(id *ast.Ident, define bool) bool {
+	if !define || id.Name == "_" {
+		return false
+	}
+	return t.info == nil || t.info.Defs[id] != nil
+}
+
+// transpileTarget escreve o lado esquerdo de uma atribuição. Elementos de map
+// são escritos sem o valor padrão usado na leitura.
+func (t *Transpiler) transpileTarget(expr ast.Expr) {
+	idx, ok := ast.Unparen(expr).(*ast.IndexExpr)
+	if !ok || !t.isMapIndex(idx) {
+		t.Transpile(expr)
+		return
+	}
+	t.Transpile(idx.X)
+	t.writeNonNull(idx.X)
+	t.write("[")
+	t.Transpile(idx.Index)
+	t.write("]")
+}
+
+// transpileMapRead traduz a leitura m[k]: chaves ausentes (e maps nil)
+// devolvem o valor zero, como no Go
+func (t *Transpiler) transpileMapRead(n *ast.IndexExpr) {
+	// Em v, ok := m[k] o tipo registrado é a tupla; usa o elemento do map
+	elem := t.typeOf(n.X).Underlying().(*types.Map).Elem()
+	nullable := nullableZero(elem)
+	if !nullable {
+		t.write("(")
+	}
+	t.Transpile(n.X)
+	if t.mayBeNull(n.X) {
+		t.write("?.get(")
+		t.Transpile(n.Index)
+		t.write(")")
+	} else {
+		t.write("[")
+		t.Transpile(n.Index)
+		t.write("]")
+	}
+	if !nullable {
+		t.write(" ?: " + t.zeroValue(elem) + ")")
+	}
+}
+
+// erasedType troca os argumentos genéricos por * para testes com is, já que
+// os tipos genéricos são apagados na JVM (ex: MutableList<Int> → MutableList<*>)
+func erasedType(ktType string) string {
+	if strings.Contains(ktType, "->") {
+		return "Function<*>"
+	}
+	start := strings.Index(ktType, "<")
+	if start < 0 {
+		return ktType
+	}
+	args, depth := 1, 0
+	for _, r := range ktType[start+1 : len(ktType)-1] {
+		switch r {
+		case '<', '(':
+			depth++
+		case '>', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				args++
+			}
+		}
+	}
+	return ktType[:start] + "<" + strings.Repeat("*, ", args-1) + "*>"
+}
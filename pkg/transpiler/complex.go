@@ -0,0 +1,134 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// collectComplex registra o uso de Complex (complex64 e complex128 viram o
+// mesmo tipo do runtime, com partes Double) e do objeto cmplx do math/cmplx
+func (t *Transpiler) collectComplex(file *ast.File) {
+	for _, imp := range file.Imports {
+		if strings.Trim(imp.Path.Value, "\"") == "math/cmplx" {
+			t.useRuntime("cmplx")
+			t.useRuntime("Complex")
+		}
+	}
+	if t.info == nil {
+		return
+	}
+	for _, tv := range t.info.Types {
+		if basic, ok := tv.Type.(*types.Basic); ok && basic.Info()&types.IsComplex != 0 {
+			t.useRuntime("Complex")
+			return
+		}
+	}
+}
+
+// transpileImagLit traduz um literal imaginário (2i) para Complex(0.0, 2.0)
+func (t *Transpiler) transpileImagLit(n *ast.BasicLit) {
+	if t.info != nil {
+		if tv, ok := t.info.Types[n]; ok && tv.Value != nil {
+			if literal, ok := t.constLiteral(tv.Value, tv.Type); ok {
+				t.write(literal)
+				return
+			}
+		}
+	}
+	imag, ok := kotlinNumberLit(strings.TrimSuffix(n.Value, "i"), token.FLOAT)
+	if !ok {
+		t.write(n.Value)
+		return
+	}
+	if !strings.ContainsAny(imag, ".eE") {
+		imag += ".0"
+	}
+	t.useRuntime("Complex")
+	t.write("Complex(0.0, " + imag + ")")
+}
+
+// transpileComplexConst escreve expressões complexas constantes (1 + 2i) já calculadas
+func (t *Transpiler) transpileComplexConst(expr ast.Expr) bool {
+	if t.info == nil {
+		return false
+	}
+	tv, ok := t.info.Types[expr]
+	if !ok || tv.Value == nil {
+		return false
+	}
+	if basic, ok := tv.Type.Underlying().(*types.Basic); !ok || basic.Info()&types.IsComplex == 0 {
+		return false
+	}
+	literal, ok := t.constLiteral(tv.Value, tv.Type)
+	if ok {
+		t.write(literal)
+	}
+	return ok
+}
+
+// transpileComplexBuiltin traduz real, imag e complex. As partes de um
+// complex64 são Float no Kotlin e convertidas de/para os Double do Complex.
+func (t *Transpiler) transpileComplexBuiltin(call *ast.CallExpr, name string) bool {
+	switch name {
+	case "real", "imag":
+		if len(call.Args) != 1 {
+			return false
+		}
+		t.transpileOperand(call.Args[0])
+		if name == "real" {
+			t.write(".re")
+		} else {
+			t.write(".im")
+		}
+		if t.isFloat32(call) {
+			t.write(".toFloat()")
+		}
+	case "complex":
+		if len(call.Args) != 2 {
+			return false
+		}
+		t.useRuntime("Complex")
+		t.write("Complex(")
+		for i, arg := range call.Args {
+			if i > 0 {
+				t.write(", ")
+			}
+			if literal, ok := t.floatConst(arg); ok {
+				t.write(literal)
+				continue
+			}
+			if t.isFloat32(arg) {
+				t.transpileOperand(arg)
+				t.write(".toDouble()")
+			} else {
+				t.Transpile(arg)
+			}
+		}
+		t.write(")")
+	default:
+		return false
+	}
+	return true
+}
+
+// floatConst devolve uma parte constante de complex() como literal Double
+func (t *Transpiler) floatConst(arg ast.Expr) (string, bool) {
+	val, ok := t.constValue(arg)
+	if !ok {
+		return "", false
+	}
+	return doubleLiteral(constant.ToFloat(val))
+}
+
+// isFloat32 informa se a expressão tem o tipo float32 (Float no Kotlin)
+func (t *Transpiler) isFloat32(expr ast.Expr) bool {
+	typ := t.typeOf(expr)
+	if typ == nil {
+		return false
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Float32
+}
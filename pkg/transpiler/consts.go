@@ -0,0 +1,469 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// handleConstDecl traduz um bloco const usando os valores já avaliados pelo
+// go/types, o que resolve iota, repetição implícita e constantes tipadas.
+func (t *Transpiler) handleConstDecl(n *ast.GenDecl) {
+	if named := t.enumDeclType(n); named != nil {
+		t.writeEnumClass(named, n)
+		return
+	}
+
+	first := true
+	for _, spec := range n.Specs {
+		vspec := spec.(*ast.ValueSpec)
+		for _, name := range vspec.Names {
+			obj, ok := t.objectOf(name).(*types.Const)
+			if !ok || name.Name == "_" {
+				continue
+			}
+			if !first {
+				t.write("\n")
+				t.writeIndent()
+			}
+			first = false
+
+			typ := obj.Type()
+			literal, ok := t.constLiteral(obj.Val(), typ)
+			if !ok {
+				t.write("// const " + name.Name + ": valor sem representação em Kotlin (" + obj.Val().ExactString() + ")")
+				continue
+			}
+
+			basic, isBasic := typ.(*types.Basic)
+			if isBasic && basic.Info()&types.IsComplex == 0 && t.isPackageLevel(obj) {
+				t.write("const ")
+			}
+			t.write("val " + t.ident(name))
+			if vspec.Type != nil {
+				t.write(": " + t.resolveType(vspec.Type))
+			} else if basic, ok := typ.(*types.Basic); !ok || basic.Info()&types.IsUntyped == 0 {
+				t.write(": " + t.resolveGoType(typ))
+			}
+			if named := t.valueClassOf(typ); named != nil {
+				literal = t.objName(named.Obj()) + "(" + literal + ")"
+			}
+			t.write(" = " + literal)
+		}
+	}
+}
+
+// constLiteral formata um valor constante como literal Kotlin do tipo correspondente
+func (t *Transpiler) constLiteral(val constant.Value, typ types.Type) (string, bool) {
+	if isTimeType(typ, "Duration") {
+		return t.durationLiteral(val)
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return "", false
+	}
+	ktType := kotlinBasicType(basic, val)
+
+	switch ktType {
+	case "Boolean":
+		return strconv.FormatBool(constant.BoolVal(val)), true
+	case "String":
+		return quoteKotlinString(constant.StringVal(val)), true
+	case "Char":
+		r, ok := constant.Int64Val(constant.ToInt(val))
+		if !ok {
+			return "", false
+		}
+		return quoteKotlinChar(rune(r)), true
+	case "Double", "Float":
+		lit, ok := doubleLiteral(constant.ToFloat(val))
+		if ok && ktType == "Float" {
+			lit += "f"
+		}
+		return lit, ok
+	case "Complex":
+		re, okRe := doubleLiteral(constant.Real(val))
+		im, okIm := doubleLiteral(constant.Imag(val))
+		if !okRe || !okIm {
+			return "", false
+		}
+		t.useRuntime("Complex")
+		return "Complex(" + re + ", " + im + ")", true
+	}
+
+	iv := constant.ToInt(val)
+	if iv.Kind() != constant.Int {
+		return "", false
+	}
+	if strings.HasPrefix(ktType, "U") {
+		u, ok := constant.Uint64Val(iv)
+		if !ok {
+			return "", false
+		}
+		lit := strconv.FormatUint(u, 10) + "u"
+		if ktType == "ULong" {
+			lit += "L"
+		}
+		return lit, true
+	}
+	i, ok := constant.Int64Val(iv)
+	if !ok {
+		return "", false
+	}
+	switch {
+	case ktType == "Long" && i == math.MinInt64:
+		return "Long.MIN_VALUE", true
+	case ktType == "Long":
+		return strconv.FormatInt(i, 10) + "L", true
+	case ktType == "Int" && i == math.MinInt32:
+		return "Int.MIN_VALUE", true
+	}
+	return strconv.FormatInt(i, 10), true
+}
+
+// kotlinBasicType devolve o tipo Kotlin de um tipo básico do Go. Constantes
+// não tipadas assumem o tipo padrão do Go; inteiros que não cabem em Int viram Long.
+func kotlinBasicType(basic *types.Basic, val constant.Value) string {
+	switch basic.Kind() {
+	case types.UntypedBool:
+		return "Boolean"
+	case types.UntypedString:
+		return "String"
+	case types.UntypedRune:
+		return "Char"
+	case types.UntypedFloat:
+		return "Double"
+	case types.UntypedComplex:
+		return "Complex"
+	case types.UntypedInt:
+		if val != nil {
+			if i, ok := constant.Int64Val(constant.ToInt(val)); ok && (i < math.MinInt32 || i > math.MaxInt32) {
+				return "Long"
+			}
+		}
+		return "Int"
+	}
+	if kt, ok := typeMapping[basic.Name()]; ok {
+		return kt
+	}
+	return basic.Name()
+}
+
+// collectEnums identifica os tipos inteiros nomeados que podem virar enum class:
+// possuem método String() e todas as suas constantes estão em um único bloco.
+// Tipos usados em aritmética (d++, d + 1) continuam como value class, assim
+// como os comparados com < e > cujos valores não crescem na ordem declarada.
+func (t *Transpiler) collectEnums(file *ast.File) {
+	if !t.options.EnumClasses || t.info == nil {
+		return
+	}
+	groups := make(map[string]int)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, spec := range gen.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				if obj := t.objectOf(name); obj != nil {
+					if named, ok := obj.Type().(*types.Named); ok && !seen[named.Obj().Name()] {
+						seen[named.Obj().Name()] = true
+						groups[named.Obj().Name()]++
+					}
+				}
+			}
+		}
+		if named := t.enumCandidate(gen); named != nil {
+			t.enums[named.Obj().Name()] = true
+		}
+	}
+	for name, count := range groups {
+		if count > 1 {
+			delete(t.enums, name)
+		}
+	}
+	if len(t.enums) == 0 {
+		return
+	}
+	arithmetic, ordered := t.enumUses(file)
+	for name := range t.enums {
+		if arithmetic[name] || (ordered[name] && !t.enumAscending(name)) {
+			delete(t.enums, name)
+			continue
+		}
+		// Os métodos são membros da enum class, para que toString() use String()
+		t.memberTypes[name] = true
+	}
+}
+
+// enumUses devolve os tipos enum candidatos usados em operações aritméticas
+// e os comparados com operadores de ordem. Expressões constantes não contam,
+// pois são calculadas na tradução.
+func (t *Transpiler) enumUses(file *ast.File) (arithmetic, ordered map[string]bool) {
+	arithmetic = make(map[string]bool)
+	ordered = make(map[string]bool)
+	enumName := func(expr ast.Expr) string {
+		if named, ok := t.typeOf(expr).(*types.Named); ok && t.enums[named.Obj().Name()] {
+			return named.Obj().Name()
+		}
+		return ""
+	}
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.BinaryExpr:
+			if t.isConst(n) {
+				return false
+			}
+			switch n.Op {
+			case token.EQL, token.NEQ, token.LAND, token.LOR:
+			case token.LSS, token.LEQ, token.GTR, token.GEQ:
+				if name := enumName(n.X); name != "" {
+					ordered[name] = true
+				}
+			default:
+				if name := enumName(n); name != "" {
+					arithmetic[name] = true
+				}
+			}
+		case *ast.UnaryExpr:
+			if n.Op != token.AND && n.Op != token.ARROW && !t.isConst(n) {
+				if name := enumName(n); name != "" {
+					arithmetic[name] = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if name := enumName(n.X); name != "" {
+				arithmetic[name] = true
+			}
+		case *ast.AssignStmt:
+			if _, compound := compoundOps[n.Tok]; compound {
+				if name := enumName(n.Lhs[0]); name != "" {
+					arithmetic[name] = true
+				}
+			}
+		}
+		return true
+	})
+	return arithmetic, ordered
+}
+
+// enumConsts devolve as constantes de um tipo enum na ordem de declaração
+func (t *Transpiler) enumConsts(named *types.Named) []*types.Const {
+	var consts []*types.Const
+	scope := named.Obj().Parent()
+	if scope == nil {
+		return nil
+	}
+	for _, name := range scope.Names() {
+		if c, ok := scope.Lookup(name).(*types.Const); ok && c.Type() == named && name != "_" {
+			consts = append(consts, c)
+		}
+	}
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+	return consts
+}
+
+// enumAscending informa se os valores do enum crescem na ordem declarada,
+// caso em que a ordem das entries (compareTo) coincide com a dos valores
+func (t *Transpiler) enumAscending(name string) bool {
+	named, ok := t.pkg.Scope().Lookup(name).Type().(*types.Named)
+	if !ok {
+		return false
+	}
+	consts := t.enumConsts(named)
+	for i := 1; i < len(consts); i++ {
+		if constant.Compare(consts[i-1].Val(), token.GEQ, consts[i].Val()) {
+			return false
+		}
+	}
+	return true
+}
+
+// enumIndexed informa se os valores do enum são 0, 1, 2... na ordem
+// declarada, caso em que o valor é o índice da entry (Color.entries[i])
+func (t *Transpiler) enumIndexed(named *types.Named) bool {
+	for i, c := range t.enumConsts(named) {
+		if constant.Compare(c.Val(), token.NEQ, constant.MakeInt64(int64(i))) {
+			return false
+		}
+	}
+	return true
+}
+
+// enumOf devolve o tipo nomeado se ele for traduzido como enum class
+func (t *Transpiler) enumOf(typ types.Type) *types.Named {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() != t.pkg || !t.enums[named.Obj().Name()] {
+		return nil
+	}
+	return named
+}
+
+// enumEntry devolve a entry (Color.Red) do enum com o valor, ou "" se não houver
+func (t *Transpiler) enumEntry(named *types.Named, val constant.Value) string {
+	for _, c := range t.enumConsts(named) {
+		if constant.Compare(c.Val(), token.EQL, val) {
+			return t.objName(named.Obj()) + "." + t.objName(c)
+		}
+	}
+	return ""
+}
+
+// transpileEnumConst escreve uma constante usada onde se espera um enum
+// (var y Color = 1, Color(2)) como a entry de mesmo valor
+func (t *Transpiler) transpileEnumConst(expr ast.Expr) bool {
+	if t.info == nil || len(t.enums) == 0 {
+		return false
+	}
+	tv, ok := t.info.Types[expr]
+	if !ok || tv.Value == nil {
+		return false
+	}
+	named := t.enumOf(tv.Type)
+	if named == nil {
+		return false
+	}
+	if id, ok := ast.Unparen(expr).(*ast.Ident); ok && t.enumConstOwner(id) != "" {
+		return false
+	}
+	if entry := t.enumEntry(named, tv.Value); entry != "" {
+		t.write(entry)
+		return true
+	}
+	t.diagnose(expr.Pos(), "valor "+tv.Value.ExactString()+" não corresponde a nenhuma constante de "+named.Obj().Name()+"; a conversão falha em tempo de execução")
+	t.write(t.objName(named.Obj()) + ".entries.first { it.value == " + tv.Value.ExactString() + " }")
+	return true
+}
+
+// transpileEnumConversion traduz Color(x) de um inteiro para a entry de
+// mesmo valor: Color.entries[x] quando os valores são os índices
+func (t *Transpiler) transpileEnumConversion(named *types.Named, arg ast.Expr, src types.Type) {
+	if t.enumOf(src) == named {
+		t.Transpile(arg)
+		return
+	}
+	name := t.objName(named.Obj())
+	if t.enumIndexed(named) {
+		t.write(name + ".entries[")
+		t.writeIntOperand(arg, "Int", false)
+		t.write("]")
+		return
+	}
+	inner := kotlinBasicType(named.Underlying().(*types.Basic), nil)
+	t.write(name + ".entries.first { it.value == ")
+	t.writeIntOperand(arg, inner, false)
+	t.write(" }")
+}
+
+// enumCandidate verifica se todas as constantes do bloco têm o mesmo tipo
+// inteiro nomeado, declarado neste pacote e com método String()
+func (t *Transpiler) enumCandidate(gen *ast.GenDecl) *types.Named {
+	var named *types.Named
+	for _, spec := range gen.Specs {
+		for _, name := range spec.(*ast.ValueSpec).Names {
+			obj, ok := t.objectOf(name).(*types.Const)
+			if !ok || !t.isPackageLevel(obj) {
+				return nil
+			}
+			nt, ok := obj.Type().(*types.Named)
+			if !ok || (named != nil && nt != named) {
+				return nil
+			}
+			named = nt
+		}
+	}
+	if named == nil || named.Obj().Pkg() != t.pkg {
+		return nil
+	}
+	if basic, ok := named.Underlying().(*types.Basic); !ok || basic.Info()&types.IsInteger == 0 {
+		return nil
+	}
+	method, _, _ := types.LookupFieldOrMethod(named, true, t.pkg, "String")
+	if _, ok := method.(*types.Func); !ok {
+		return nil
+	}
+	return named
+}
+
+// enumDeclType devolve o tipo da enum class gerada por este bloco const, se houver
+func (t *Transpiler) enumDeclType(gen *ast.GenDecl) *types.Named {
+	if len(t.enums) == 0 {
+		return nil
+	}
+	if named := t.enumCandidate(gen); named != nil && t.enums[named.Obj().Name()] {
+		return named
+	}
+	return nil
+}
+
+// doubleLiteral formata uma constante real como literal Double
+func doubleLiteral(val constant.Value) (string, bool) {
+	f, _ := constant.Float64Val(val)
+	if math.IsInf(f, 0) || val.Kind() == constant.Unknown {
+		return "", false
+	}
+	lit := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(lit, ".e") {
+		lit += ".0"
+	}
+	return lit, true
+}
+
+// writeEnumClass gera a enum class com o valor inteiro de cada constante
+func (t *Transpiler) writeEnumClass(named *types.Named, gen *ast.GenDecl) {
+	basic := named.Underlying().(*types.Basic)
+	t.write("enum class " + t.objName(named.Obj()) + "(val value: " + kotlinBasicType(basic, nil) + ") {\n")
+	t.indent()
+	var entries []string
+	for _, spec := range gen.Specs {
+		for _, name := range spec.(*ast.ValueSpec).Names {
+			obj := t.objectOf(name).(*types.Const)
+			if name.Name == "_" {
+				continue
+			}
+			literal, _ := t.constLiteral(obj.Val(), basic)
+			entries = append(entries, t.ident(name)+"("+literal+")")
+		}
+	}
+	for i, entry := range entries {
+		t.writeIndent()
+		t.write(entry)
+		if i < len(entries)-1 {
+			t.write(",\n")
+		} else {
+			t.write(";\n")
+		}
+	}
+
+	// Go imprime o resultado de String(), e não o nome da constante
+	t.write("\n")
+	t.writeLine("override fun toString() = String()")
+	if t.hasMembers(named.Obj().Name()) {
+		t.write("\n")
+		t.writeMembers(named.Obj().Name())
+	}
+	t.unindent()
+	t.writeIndent()
+	t.write("}")
+}
+
+// enumConstOwner devolve o nome da enum class à qual a constante pertence
+func (t *Transpiler) enumConstOwner(id *ast.Ident) string {
+	if len(t.enums) == 0 {
+		return ""
+	}
+	obj, ok := t.objectOf(id).(*types.Const)
+	if !ok {
+		return ""
+	}
+	if named, ok := obj.Type().(*types.Named); ok && t.enums[named.Obj().Name()] && t.isPackageLevel(obj) {
+		return t.objName(named.Obj())
+	}
+	return ""
+}
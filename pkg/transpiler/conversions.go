@@ -0,0 +1,251 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+)
+
+// transpileConversion trata chamadas que são conversões de tipo, como
+// Celsius(x) ou float64(c). Devolve false se a chamada não for uma conversão
+// que precise de tradução especial.
+func (t *Transpiler) transpileConversion(call *ast.CallExpr) bool {
+	if t.info == nil || len(call.Args) != 1 {
+		return false
+	}
+	tv, ok := t.info.Types[call.Fun]
+	if !ok || !tv.IsType() {
+		return false
+	}
+	target := tv.Type
+	arg := call.Args[0]
+	src := t.typeOf(arg)
+
+	if t.transpileEnumConst(call) {
+		return true
+	}
+	if result, ok := t.info.Types[call]; ok && result.Value != nil {
+		if literal, ok := t.constLiteral(result.Value, target); ok {
+			if named := t.valueClassOf(target); named != nil {
+				literal = t.objName(named.Obj()) + "(" + literal + ")"
+			}
+			t.write(literal)
+			return true
+		}
+	}
+	if src == nil {
+		return false
+	}
+	if _, isParam := target.(*types.TypeParam); isParam {
+		return false
+	}
+	if t.transpileDurationConversion(arg, src, target) {
+		return true
+	}
+
+	if named := t.enumOf(target); named != nil {
+		t.transpileEnumConversion(named, arg, src)
+		return true
+	}
+	if named := t.valueClassOf(target); named != nil {
+		t.write(t.objName(named.Obj()) + "(")
+		if !t.writeConverted(arg, src, named.Underlying().(*types.Basic)) {
+			t.transpileUnwrapped(arg, src)
+		}
+		t.write(")")
+		return true
+	}
+
+	switch dst := target.Underlying().(type) {
+	case *types.Basic:
+		if t.writeConverted(arg, src, dst) || t.transpileSliceToString(arg, src, dst) {
+			return true
+		}
+	case *types.Slice:
+		return t.transpileSliceConversion(arg, src, dst)
+	case *types.Interface:
+		// Conversão para interface (ex: any(x)) não muda o valor
+		t.Transpile(arg)
+		return true
+	}
+
+	if t.hasValue(src) {
+		t.transpileUnwrapped(arg, src)
+		return true
+	}
+	return false
+}
+
+// writeConverted escreve arg convertido para o tipo básico dst: entre números
+// usa .toX(), runas passam por .code/.toChar() e inteiros viram a string do
+// code point. Floats para Byte/Short passam por Int, pois o Kotlin não converte direto.
+func (t *Transpiler) writeConverted(arg ast.Expr, src types.Type, dst *types.Basic) bool {
+	srcBasic, ok := src.Underlying().(*types.Basic)
+	// complex64 e complex128 são o mesmo Complex; real e complexo não se convertem
+	if !ok || (srcBasic.Info()&types.IsComplex != 0) != (dst.Info()&types.IsComplex != 0) {
+		return false
+	}
+	srcKt := kotlinBasicType(srcBasic, nil)
+	dstKt := kotlinBasicType(dst, nil)
+	class := t.hasValue(src)
+
+	operand := func() {
+		t.transpileOperand(arg)
+		if class {
+			t.write(".value")
+		}
+	}
+
+	if dst.Info()&types.IsString != 0 {
+		switch {
+		case srcBasic.Info()&types.IsString != 0:
+			t.transpileUnwrapped(arg, src)
+		case srcKt == "Char":
+			operand()
+			t.write(".toString()")
+		case srcBasic.Info()&types.IsInteger != 0:
+			// string(i) no Go é o caractere do code point i
+			t.write("String(Character.toChars(")
+			if srcKt == "Int" {
+				t.transpileUnwrapped(arg, src)
+			} else {
+				operand()
+				t.write(".toInt()")
+			}
+			t.write("))")
+		default:
+			return false
+		}
+		return true
+	}
+
+	if srcBasic.Info()&types.IsNumeric == 0 || dst.Info()&types.IsNumeric == 0 {
+		return false
+	}
+	if srcKt == dstKt {
+		t.transpileUnwrapped(arg, src)
+		return true
+	}
+	if bin, ok := ast.Unparen(arg).(*ast.BinaryExpr); ok && srcKt == "Char" && !class && dstKt != "Char" {
+		// Operações entre runas já são Int no Kotlin (Char - Char), sem .code
+		t.write("(")
+		t.transpileRuneOp(bin, true)
+		t.write(")")
+		if dstKt != "Int" {
+			t.write(".to" + dstKt + "()")
+		}
+		return true
+	}
+	operand()
+	if srcKt == "Char" {
+		t.write(".code")
+		srcKt = "Int"
+		if dstKt == "Int" {
+			return true
+		}
+	}
+	float := srcKt == "Double" || srcKt == "Float"
+	switch {
+	case dstKt == "Char" && srcKt != "Int":
+		t.write(".toInt().toChar()")
+	case float && (dstKt == "Byte" || dstKt == "Short"):
+		t.write(".toInt().to" + dstKt + "()")
+	case float && (dstKt == "UByte" || dstKt == "UShort"):
+		t.write(".toUInt().to" + dstKt + "()")
+	default:
+		t.write(".to" + dstKt + "()")
+	}
+	return true
+}
+
+// transpileSliceConversion traduz []byte(s) e []rune(s) para listas de bytes
+// (UTF-8) ou de code points
+func (t *Transpiler) transpileSliceConversion(arg ast.Expr, src types.Type, dst *types.Slice) bool {
+	srcBasic, ok := src.Underlying().(*types.Basic)
+	if !ok || srcBasic.Info()&types.IsString == 0 {
+		return false
+	}
+	elem, ok := dst.Elem().Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	switch elem.Kind() {
+	case types.Uint8:
+		t.transpileUnwrapped(arg, src)
+		t.write(".toByteArray().map { it.toUByte() }.toMutableList()")
+	case types.Int32:
+		// rune vira Char, que só representa o BMP: emojis e outros code
+		// points acima de U+FFFF não cabem e seriam truncados
+		if tv, ok := t.info.Types[arg]; !ok || tv.Value == nil || !bmpOnly(constant.StringVal(tv.Value)) {
+			t.diagnose(arg.Pos(), "[]rune vira MutableList<Char>: code points acima de U+FFFF (ex: emojis) não são representáveis e geram erro em tempo de execução")
+		}
+		t.transpileUnwrapped(arg, src)
+		t.write(".codePoints().toArray().map { Char(it) }.toMutableList()")
+	default:
+		return false
+	}
+	return true
+}
+
+// transpileSliceToString traduz string(b) e string(r) de listas de bytes ou runas
+func (t *Transpiler) transpileSliceToString(arg ast.Expr, src types.Type, dst *types.Basic) bool {
+	slice, ok := src.Underlying().(*types.Slice)
+	if !ok || dst.Info()&types.IsString == 0 {
+		return false
+	}
+	elem, ok := slice.Elem().Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	switch elem.Kind() {
+	case types.Uint8:
+		t.write("String(")
+		t.transpileOperand(arg)
+		t.write(".map { it.toByte() }.toByteArray())")
+	case types.Int32:
+		t.transpileOperand(arg)
+		t.write(`.joinToString("")`)
+	default:
+		return false
+	}
+	return true
+}
+
+// transpileUnwrapped escreve a expressão desembrulhando value classes (x.value)
+func (t *Transpiler) transpileUnwrapped(expr ast.Expr, typ types.Type) {
+	if !t.hasValue(typ) {
+		t.Transpile(expr)
+		return
+	}
+	t.transpileOperand(expr)
+	t.write(".value")
+}
+
+// hasValue informa se o tipo é traduzido como value class ou enum class,
+// cujo valor Go fica na propriedade value
+func (t *Transpiler) hasValue(typ types.Type) bool {
+	return typ != nil && (t.valueClassOf(typ) != nil || t.enumOf(typ) != nil)
+}
+
+// transpileOperand escreve a expressão entre parênteses quando ela for
+// usada como receptor de uma chamada (ex: (a + b).value)
+func (t *Transpiler) transpileOperand(expr ast.Expr) {
+	switch expr.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.CallExpr, *ast.IndexExpr, *ast.ParenExpr, *ast.BasicLit, *ast.CompositeLit:
+		t.Transpile(expr)
+	default:
+		t.write("(")
+		t.Transpile(expr)
+		t.write(")")
+	}
+}
+
+// bmpOnly informa se todos os caracteres da string cabem em um Char do Kotlin
+func bmpOnly(s string) bool {
+	for _, r := range s {
+		if r > 0xFFFF {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// errorsFuncs são as funções do pacote errors oferecidas pelo objeto errors do runtime
+var errorsFuncs = map[string]bool{
+	"New":    true,
+	"Is":     true,
+	"Unwrap": true,
+	"Join":   true,
+}
+
+// transpileErrors traduz as chamadas ao pacote errors. errors.As(err, &target)
+// vira errors.As<T>(err) { target = it }, pois o Kotlin não tem ponteiros.
+func (t *Transpiler) transpileErrors(call *ast.CallExpr) bool {
+	name := t.pkgMember(call.Fun, "errors")
+	switch {
+	case name == "As":
+		target, elem := t.asTarget(call)
+		if target == nil {
+			t.diagnose(call.Pos(), "errors.As sem &variável como destino; chamada mantida")
+			return false
+		}
+		t.useRuntime("errors")
+		t.write("errors.As<" + strings.TrimSuffix(t.resolveGoType(elem), "?") + ">(")
+		t.Transpile(call.Args[0])
+		t.write(") { " + t.ident(target) + " = it }")
+		return true
+	case errorsFuncs[name]:
+		t.useRuntime("errors")
+		t.write("errors." + name)
+		t.writeCallArgs(call)
+		return true
+	case name != "":
+		t.diagnose(call.Pos(), "errors."+name+" sem tradução para Kotlin; chamada mantida")
+	}
+	return false
+}
+
+// asTarget devolve a variável x de errors.As(err, &x) e o seu tipo
+func (t *Transpiler) asTarget(call *ast.CallExpr) (*ast.Ident, types.Type) {
+	if len(call.Args) != 2 {
+		return nil, nil
+	}
+	addr, ok := ast.Unparen(call.Args[1]).(*ast.UnaryExpr)
+	if !ok || addr.Op != token.AND {
+		return nil, nil
+	}
+	id, ok := ast.Unparen(addr.X).(*ast.Ident)
+	if !ok {
+		return nil, nil
+	}
+	typ := t.typeOf(id)
+	if typ == nil {
+		return nil, nil
+	}
+	return id, typ
+}
@@ -0,0 +1,363 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// fmtVerb é uma diretiva de uma string de formato do Go (ex: %-8.2f)
+type fmtVerb struct {
+	flags     string
+	width     string
+	precision string // inclui o ponto (ex: ".2"); vazio quando ausente
+	verb      rune
+}
+
+// spec devolve a diretiva completa, com o % inicial
+func (v fmtVerb) spec() string {
+	return "%" + v.flags + v.width + v.precision + string(v.verb)
+}
+
+// plain informa se a diretiva não tem flags, largura nem precisão
+func (v fmtVerb) plain() bool {
+	return v.flags == "" && v.width == "" && v.precision == ""
+}
+
+// parseFormat separa uma string de formato do Go em textos e diretivas, com
+// um texto a mais que o número de diretivas. Índices explícitos (%[1]d),
+// larguras com * e diretivas incompletas ficam para o goSprintf (ok falso).
+func parseFormat(format string) (texts []string, verbs []fmtVerb, ok bool) {
+	var text strings.Builder
+	runes := []rune(format)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			text.WriteRune(runes[i])
+			continue
+		}
+		var v fmtVerb
+		j := i + 1
+		for j < len(runes) && strings.ContainsRune("+-# 0", runes[j]) {
+			v.flags += string(runes[j])
+			j++
+		}
+		for j < len(runes) && runes[j] >= '0' && runes[j] <= '9' {
+			v.width += string(runes[j])
+			j++
+		}
+		if j < len(runes) && runes[j] == '.' {
+			v.precision = "."
+			j++
+			for j < len(runes) && runes[j] >= '0' && runes[j] <= '9' {
+				v.precision += string(runes[j])
+				j++
+			}
+		}
+		if j >= len(runes) || runes[j] == '*' || runes[j] == '[' {
+			return nil, nil, false
+		}
+		i = j
+		if runes[j] == '%' && v.plain() {
+			text.WriteRune('%')
+			continue
+		}
+		v.verb = runes[j]
+		texts = append(texts, text.String())
+		text.Reset()
+		verbs = append(verbs, v)
+	}
+	return append(texts, text.String()), verbs, true
+}
+
+// fmtCall devolve o nome da função do pacote fmt chamada (ex: "Printf")
+func (t *Transpiler) fmtCall(call *ast.CallExpr) string {
+	return t.pkgMember(call.Fun, "fmt")
+}
+
+// transpileFmt traduz as funções de impressão e formatação do pacote fmt.
+// Formatos constantes viram string templates; os demais usam goSprintf.
+func (t *Transpiler) transpileFmt(call *ast.CallExpr) bool {
+	switch t.fmtCall(call) {
+	case "Println":
+		t.write("println(")
+		if len(call.Args) > 0 {
+			t.writeTemplate(call.Args, alwaysSpaced, "")
+		}
+		t.write(")")
+	case "Print":
+		t.write("print(")
+		t.writeTemplate(call.Args, t.printSpaced, "")
+		t.write(")")
+	case "Printf":
+		if len(call.Args) == 0 {
+			return false
+		}
+		t.write("print(")
+		t.transpileFormat(call.Args[0], call.Args[1:])
+		t.write(")")
+	case "Sprint":
+		t.writeTemplate(call.Args, t.printSpaced, "")
+	case "Sprintln":
+		t.writeTemplate(call.Args, alwaysSpaced, `\n`)
+	case "Sprintf":
+		if len(call.Args) == 0 {
+			return false
+		}
+		t.transpileFormat(call.Args[0], call.Args[1:])
+	case "Fprint", "Fprintln", "Fprintf":
+		return t.transpileFprint(call, t.fmtCall(call))
+	case "Errorf":
+		if len(call.Args) == 0 {
+			return false
+		}
+		t.transpileErrorf(call.Args[0], call.Args[1:])
+	default:
+		return false
+	}
+	return true
+}
+
+// transpileErrorf traduz fmt.Errorf. Sem %w o erro é um errors.New com o
+// texto formatado; com %w, wrapError guarda também os erros embrulhados.
+// Formatos não constantes ou %w com argumentos de efeito colateral usam goErrorf.
+func (t *Transpiler) transpileErrorf(format ast.Expr, args []ast.Expr) {
+	var wrapped []ast.Expr
+	stable := false
+	if val, ok := t.constValue(format); ok && val.Kind() == constant.String {
+		_, verbs, parsed := parseFormat(constant.StringVal(val))
+		stable = parsed && len(verbs) == len(args)
+		for i := 0; stable && i < len(verbs); i++ {
+			if verbs[i].verb == 'w' {
+				wrapped = append(wrapped, args[i])
+				stable = t.isStableArg(args[i])
+			}
+		}
+	}
+	switch {
+	case !stable:
+		t.useRuntime("goErrorf")
+		t.write("goErrorf(")
+		t.Transpile(format)
+		for _, arg := range args {
+			t.write(", ")
+			t.Transpile(arg)
+		}
+		t.write(")")
+	case len(wrapped) == 0:
+		t.useRuntime("errors")
+		t.write("errors.New(")
+		t.transpileFormat(format, args)
+		t.write(")")
+	default:
+		t.useRuntime("wrapError")
+		t.write("wrapError(")
+		t.transpileFormat(format, args)
+		for _, arg := range wrapped {
+			t.write(", ")
+			t.Transpile(arg)
+		}
+		t.write(")")
+	}
+}
+
+// alwaysSpaced separa todos os operandos com espaço, como fmt.Println
+func alwaysSpaced(_, _ ast.Expr) bool {
+	return true
+}
+
+// neverSpaced concatena os operandos sem separador, como o builtin print
+func neverSpaced(_, _ ast.Expr) bool {
+	return false
+}
+
+// printSpaced segue a regra de fmt.Print: espaço entre operandos apenas
+// quando nenhum dos dois é uma string
+func (t *Transpiler) printSpaced(prev, next ast.Expr) bool {
+	return !t.isStringExpr(prev) && !t.isStringExpr(next)
+}
+
+// isStringExpr informa se a expressão tem um tipo string
+func (t *Transpiler) isStringExpr(expr ast.Expr) bool {
+	typ := t.typeOf(expr)
+	if typ == nil {
+		_, isLit := expr.(*ast.BasicLit)
+		return isLit
+	}
+	b, ok := typ.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsString != 0
+}
+
+// transpileFormat escreve a String Kotlin equivalente a fmt.Sprintf(format, args...).
+// Cada diretiva vira um trecho do template: o próprio valor, String.format com
+// o verbo Java equivalente ou goSprintf para os verbos sem equivalente.
+func (t *Transpiler) transpileFormat(format ast.Expr, args []ast.Expr) {
+	var tv types.TypeAndValue
+	if t.info != nil {
+		tv = t.info.Types[format]
+	}
+	if tv.Value != nil && tv.Value.Kind() == constant.String {
+		texts, verbs, ok := parseFormat(constant.StringVal(tv.Value))
+		if ok && len(verbs) == len(args) {
+			var text strings.Builder
+			t.write(`"`)
+			for i, v := range verbs {
+				text.WriteString(texts[i])
+				if name, static := t.staticTypeName(v, args[i]); static {
+					text.WriteString(name)
+					continue
+				}
+				t.write(escapeKotlinText(text.String()))
+				text.Reset()
+				t.write("${")
+				t.writeVerb(v, args[i])
+				t.write("}")
+			}
+			text.WriteString(texts[len(verbs)])
+			t.write(escapeKotlinText(text.String()) + `"`)
+			return
+		}
+	}
+	t.useRuntime("goSprintf")
+	t.write("goSprintf(")
+	t.Transpile(format)
+	for _, arg := range args {
+		t.write(", ")
+		t.Transpile(arg)
+	}
+	t.write(")")
+}
+
+// staticTypeName resolve %T em tempo de tradução quando o tipo do argumento
+// não é uma interface
+func (t *Transpiler) staticTypeName(v fmtVerb, arg ast.Expr) (string, bool) {
+	if v.verb != 'T' || !v.plain() {
+		return "", false
+	}
+	typ := t.typeOf(arg)
+	if typ == nil || types.IsInterface(typ) {
+		return "", false
+	}
+	return types.TypeString(types.Default(typ), func(p *types.Package) string { return p.Name() }), true
+}
+
+// writeVerb escreve o trecho do template correspondente a uma diretiva
+func (t *Transpiler) writeVerb(v fmtVerb, arg ast.Expr) {
+	if v.verb == 'w' {
+		// %w (fmt.Errorf) imprime o erro como %v
+		v.verb = 'v'
+	}
+	kind := t.fmtKind(arg)
+	if v.verb == 'v' && v.width == "" && v.precision == "" && (v.flags == "" || v.flags == "+" || v.flags == "#") && t.needsGoFormat(arg) {
+		t.writeGoFormat(arg, v.flags)
+		return
+	}
+	if v.plain() {
+		switch {
+		case v.verb == 'v' && kind != "float" && kind != "char" && kind != "",
+			v.verb == 's' && kind == "string",
+			v.verb == 'd' && (kind == "int" || kind == "uint"),
+			v.verb == 't' && kind == "bool":
+			t.Transpile(arg)
+			return
+		}
+	}
+	// Verbos com o mesmo significado no String.format do Java
+	javaFlags := strings.Trim(v.flags, "-+ 0") == ""
+	switch {
+	case javaFlags && v.verb == 'd' && kind == "int" && v.precision == "",
+		javaFlags && kind == "float" && strings.ContainsRune("eEf", v.verb):
+		t.write(quoteKotlinString(v.spec()) + ".format(java.util.Locale.ROOT, ")
+		t.Transpile(arg)
+		t.write(")")
+	case javaFlags && v.verb == 'F' && kind == "float":
+		t.write(quoteKotlinString("%"+v.flags+v.width+v.precision+"f") + ".format(java.util.Locale.ROOT, ")
+		t.Transpile(arg)
+		t.write(")")
+	case v.flags == "" || v.flags == "-":
+		if v.verb == 's' && kind == "string" {
+			t.write(quoteKotlinString(v.spec()) + ".format(")
+			t.Transpile(arg)
+			t.write(")")
+			return
+		}
+		fallthrough
+	default:
+		t.useRuntime("goSprintf")
+		t.write("goSprintf(" + quoteKotlinString(v.spec()) + ", ")
+		t.Transpile(arg)
+		t.write(")")
+	}
+}
+
+// fmtKind classifica o argumento de uma diretiva pelo tipo Kotlin gerado:
+// "int" (inteiros com sinal), "uint", "char", "float", "string", "bool",
+// "other" ou "" se o tipo é desconhecido. O String.format do Java só formata
+// como o Go os inteiros com sinal.
+func (t *Transpiler) fmtKind(arg ast.Expr) string {
+	typ := t.typeOf(arg)
+	if typ == nil {
+		return ""
+	}
+	if t.valueClassOf(typ) != nil || t.enums[t.resolveGoType(typ)] || isTimeType(typ, "Duration") {
+		return "other"
+	}
+	b, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return "other"
+	}
+	switch ktType := kotlinBasicType(b, nil); {
+	case ktType == "Char":
+		return "char"
+	case b.Info()&types.IsUnsigned != 0:
+		return "uint"
+	case b.Info()&types.IsInteger != 0:
+		return "int"
+	case b.Info()&types.IsFloat != 0:
+		return "float"
+	case b.Info()&types.IsString != 0:
+		return "string"
+	case b.Info()&types.IsBoolean != 0:
+		return "bool"
+	}
+	return "other"
+}
+
+// constText devolve o texto que fmt.Print imprime para um argumento constante
+// de tipo básico, que pode ir direto para o template
+func (t *Transpiler) constText(arg ast.Expr) (string, bool) {
+	if t.info == nil {
+		return "", false
+	}
+	tv, ok := t.info.Types[arg]
+	if !ok || tv.Value == nil || t.fmtKind(arg) == "other" {
+		return "", false
+	}
+	switch tv.Value.Kind() {
+	case constant.String:
+		return constant.StringVal(tv.Value), true
+	case constant.Bool:
+		return strconv.FormatBool(constant.BoolVal(tv.Value)), true
+	case constant.Int:
+		return tv.Value.ExactString(), true
+	case constant.Float:
+		// float32 usa a menor representação que volta ao mesmo float32 (0.1, não 0.10000000149011612)
+		bits := 64
+		if b, ok := tv.Type.Underlying().(*types.Basic); ok && b.Kind() == types.Float32 {
+			bits = 32
+		}
+		f, _ := constant.Float64Val(tv.Value)
+		return strconv.FormatFloat(f, 'g', -1, bits), true
+	}
+	return "", false
+}
+
+// escapeKotlinText escapa um texto para dentro de um literal de string Kotlin
+func escapeKotlinText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		b.WriteString(escapeKotlinRune(r, '"'))
+	}
+	return b.String()
+}
@@ -0,0 +1,248 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// funcContext guarda o estado da função em tradução, consultado por return e defer
+type funcContext struct {
+	results []string // nomes dos resultados nomeados (vazio se não houver)
+	defers  bool     // a função usa defer: o corpo roda em try/finally
+	label   string   // rótulo do return em lambdas (corpo de defer func() { ... }())
+}
+
+// writeFuncBody escreve o corpo da função precedido das declarações
+// auxiliares. Resultados nomeados viram variáveis locais com o valor zero e,
+// se a função usa defer, o corpo roda em um try cujo finally executa as
+// funções adiadas em ordem inversa. Se o arquivo usa recover, o pânico é
+// capturado e entregue a runDefers, que o relança se nenhum defer o
+// interromper; interrompido, a função devolve os resultados atuais.
+func (t *Transpiler) writeFuncBody(ftype *ast.FuncType, body *ast.BlockStmt, prelude []string) {
+	if body == nil {
+		return
+	}
+	outer := t.fn
+	t.fn = &funcContext{defers: containsDefer(body)}
+	defer func() { t.fn = outer }()
+
+	var zeros []string
+	if ftype != nil && ftype.Results != nil {
+		for _, field := range ftype.Results.List {
+			ktType := t.resolveType(field.Type)
+			zero := ""
+			if typ := t.typeOf(field.Type); typ != nil {
+				ktType = nullableType(ktType, typ)
+				zero = " = " + t.zeroValue(typ)
+				if len(field.Names) == 0 {
+					zeros = append(zeros, t.zeroValue(typ))
+				}
+			}
+			for _, name := range field.Names {
+				result := t.ident(name)
+				if result == "_" {
+					result = t.freshName("result" + strconv.Itoa(len(t.fn.results)))
+				}
+				t.fn.results = append(t.fn.results, result)
+				prelude = append(prelude, "var "+result+": "+ktType+zero)
+			}
+		}
+	}
+	named := len(t.fn.results) > 0
+	recovers := t.fn.defers && t.usesRecover
+	if t.fn.defers {
+		prelude = append(prelude, "val "+t.freshName("defers")+" = mutableListOf<() -> Unit>()")
+	}
+	if recovers {
+		t.useRuntime("runDefers")
+		prelude = append(prelude, "var "+t.freshName("panicking")+": Throwable? = null")
+	}
+
+	if len(prelude) == 0 {
+		t.Transpile(body)
+		return
+	}
+	t.write("{\n")
+	t.indent()
+	for _, line := range prelude {
+		t.writeLine(line)
+	}
+	if !t.fn.defers {
+		t.writeStatements(body.List)
+	} else {
+		t.writeLine("try {")
+		t.indent()
+		if named {
+			// O return acontece depois do finally, para ver as alterações feitas pelos defers
+			t.writeLine("run " + t.freshName("body") + "@{")
+			t.indent()
+		}
+		t.writeStatements(body.List)
+		if named {
+			t.unindent()
+			t.writeLine("}")
+		}
+		t.unindent()
+		if recovers {
+			t.writeLine("} catch (e: Throwable) {")
+			t.indent()
+			t.writeLine(t.freshName("panicking") + " = e")
+			t.unindent()
+			t.writeLine("} finally {")
+			t.indent()
+			t.writeLine("runDefers(" + t.freshName("defers") + ", " + t.freshName("panicking") + ")")
+		} else {
+			t.writeLine("} finally {")
+			t.indent()
+			t.writeLine(t.freshName("defers") + ".asReversed().forEach { it() }")
+		}
+		t.unindent()
+		t.writeLine("}")
+		switch {
+		case named:
+			t.writeLine("return " + t.namedResultsValue())
+		case recovers && len(zeros) == 1:
+			// Pânico interrompido por recover: a função devolve os valores zero
+			t.writeLine("return " + zeros[0])
+		case recovers && len(zeros) > 1:
+			t.writeLine("return " + t.tupleClass(len(zeros)) + "(" + strings.Join(zeros, ", ") + ")")
+		}
+	}
+	t.unindent()
+	t.writeIndent()
+	t.write("}")
+}
+
+// writeStatements escreve uma lista de comandos, um por linha, omitindo os
+// que não geram código (_ = x)
+func (t *Transpiler) writeStatements(stmts []ast.Stmt) {
+	for _, stmt := range stmts {
+		mark := t.output.Len()
+		t.writeIndent()
+		start := t.output.Len()
+		t.Transpile(stmt)
+		if t.output.Len() == start {
+			t.output.Truncate(mark)
+			continue
+		}
+		t.write("\n")
+	}
+}
+
+// namedResultsValue devolve o valor de retorno formado pelos resultados nomeados
+func (t *Transpiler) namedResultsValue() string {
+	if len(t.fn.results) == 1 {
+		return t.fn.results[0]
+	}
+	return t.tupleClass(len(t.fn.results)) + "(" + strings.Join(t.fn.results, ", ") + ")"
+}
+
+// transpileNamedReturn traduz return em funções com resultados nomeados: o
+// return sem valores devolve as variáveis dos resultados. Com defer, os
+// valores são atribuídos aos resultados e o bloco é encerrado com return@body.
+func (t *Transpiler) transpileNamedReturn(n *ast.ReturnStmt) bool {
+	if t.fn == nil || len(t.fn.results) == 0 {
+		return false
+	}
+	if !t.fn.defers {
+		if len(n.Results) > 0 {
+			return false
+		}
+		t.write("return " + t.namedResultsValue())
+		return true
+	}
+	if len(n.Results) > 0 {
+		var lhs []ast.Expr
+		for _, name := range t.fn.results {
+			lhs = append(lhs, &ast.Ident{Name: name})
+		}
+		if len(lhs) == 1 && len(n.Results) == 1 {
+			t.write(t.fn.results[0] + " = ")
+			t.transpileReturned(n.Results[0])
+		} else {
+			t.transpileMultiAssign(&ast.AssignStmt{Lhs: lhs, Tok: token.ASSIGN, Rhs: n.Results})
+		}
+		t.write("\n")
+		t.writeIndent()
+	}
+	t.write("return@" + t.freshName("body"))
+	return true
+}
+
+// containsDefer informa se o corpo usa defer, sem contar funções aninhadas
+func containsDefer(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node.(type) {
+		case *ast.DeferStmt:
+			found = true
+		case *ast.FuncLit:
+			return false
+		}
+		return !found
+	})
+	return found
+}
+
+// handleDeferStmt registra a chamada adiada na lista de defers da função.
+// Como no Go, os argumentos são avaliados no momento do defer. O corpo de
+// defer func() { ... }() vira uma lambda cujo return é return@defer; se ele
+// também usa defer, vira uma função anônima, com o próprio try/finally.
+func (t *Transpiler) handleDeferStmt(tr *Transpiler, node ast.Node) error {
+	n := node.(*ast.DeferStmt)
+	if lit, ok := n.Call.Fun.(*ast.FuncLit); ok && len(n.Call.Args) == 0 {
+		if containsDefer(lit.Body) || lit.Type.Results != nil {
+			t.write(t.freshName("defers") + ".add(")
+			t.Transpile(lit)
+			t.write(")")
+			return nil
+		}
+		outer := t.fn
+		t.fn = &funcContext{label: t.freshName("defer")}
+		t.write(t.freshName("defers") + ".add " + t.fn.label + "@")
+		t.Transpile(lit.Body)
+		t.fn = outer
+		return nil
+	}
+	call := *n.Call
+	call.Args = nil
+	for _, arg := range n.Call.Args {
+		if t.isStableArg(arg) {
+			call.Args = append(call.Args, arg)
+			continue
+		}
+		temp := t.newTemp()
+		t.write("val " + temp + " = ")
+		t.transpileCopied(arg)
+		t.write("\n")
+		t.writeIndent()
+		call.Args = append(call.Args, &ast.Ident{NamePos: arg.Pos(), Name: temp})
+	}
+	t.write(t.freshName("defers") + ".add { ")
+	t.Transpile(&call)
+	t.write(" }")
+	return nil
+}
+
+// isStableArg informa se o argumento tem o mesmo valor quando o defer executa:
+// constantes e variáveis que nunca são reatribuídas
+func (t *Transpiler) isStableArg(arg ast.Expr) bool {
+	if t.info == nil {
+		return false
+	}
+	if tv, ok := t.info.Types[arg]; ok && tv.Value != nil {
+		return true
+	}
+	id, ok := ast.Unparen(arg).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	if _, isNil := t.objectOf(id).(*types.Nil); isNil {
+		return true
+	}
+	obj, isVar := t.objectOf(id).(*types.Var)
+	return isVar && !t.reassigned[obj] && !t.boxed[obj] && !isStructType(obj.Type())
+}
@@ -0,0 +1,48 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// goFormatFile é o arquivo de suporte emitido ao lado da saída com a opção GoOutput
+const goFormatFile = "GoFormat.kt"
+
+// needsGoFormat informa se o valor impresso precisa do goFormat para sair
+// como no Go: strings, inteiros e booleanos já têm o mesmo toString
+func (t *Transpiler) needsGoFormat(arg ast.Expr) bool {
+	if !t.options.GoOutput {
+		return false
+	}
+	switch t.fmtKind(arg) {
+	case "string", "int", "uint", "bool":
+		return false
+	}
+	return true
+}
+
+// writeGoFormat escreve a chamada goFormat(x) com as flags do verbo (+ ou #).
+// Ponteiros para struct são impressos como &{...}, o que só o tipo estático revela.
+func (t *Transpiler) writeGoFormat(arg ast.Expr, flags string) {
+	t.useRuntime("goFormat")
+	t.write("goFormat(")
+	t.Transpile(arg)
+	if flags != "" {
+		t.write(", " + quoteKotlinString(flags))
+	}
+	if typ := t.typeOf(arg); typ != nil {
+		if ptr, ok := typ.Underlying().(*types.Pointer); ok && isStructType(ptr.Elem()) {
+			t.write(", pointer = true")
+		}
+	}
+	t.write(")")
+}
+
+// goFormatSupport devolve o conteúdo do GoFormat.kt, no mesmo pacote da saída
+func (t *Transpiler) goFormatSupport() string {
+	pkg := "main"
+	if t.file != nil {
+		pkg = t.file.Name.Name
+	}
+	return "package " + pkg + "\n\n" + runtimeBody("Fmt.kt") + "\n"
+}
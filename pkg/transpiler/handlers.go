@@ -2,7 +2,10 @@ package transpiler
 
 import (
 	"go/ast"
+	"go/constant"
 	"go/token"
+	"go/types"
+	"strconv"
 	"strings"
 )
 
@@ -28,6 +31,7 @@ func (t *Transpiler) registerHandlers() {
 	t.register(&ast.GoStmt{}, t.handleGoStmt)
 	t.register(&ast.SendStmt{}, t.handleSendStmt)
 	t.register(&ast.DeclStmt{}, t.handleDeclStmt)
+	t.register(&ast.DeferStmt{}, t.handleDeferStmt)
 
 	// Expressions (Expressões)
 	t.register(&ast.CallExpr{}, t.handleCallExpr)
@@ -47,11 +51,42 @@ func (t *Transpiler) registerHandlers() {
 
 // --- IMPLEMENTAÇÃO DAS ESTRATÉGIAS ---
 
+// translatedImports são os pacotes do Go traduzidos pelo transpilador, sem import no Kotlin
+var translatedImports = map[string]bool{
+	"fmt":           true,
+	"time":          true,
+	"math/cmplx":    true,
+	"strings":       true,
+	"strconv":       true,
+	"math":          true,
+	"math/rand":     true,
+	"sort":          true,
+	"slices":        true,
+	"maps":          true,
+	"errors":        true,
+	"os":            true,
+	"bufio":         true,
+	"io":            true,
+	"encoding/json": true,
+}
+
 func (t *Transpiler) handleFile(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.File)
 	
 	// Pre-analysis pass
+	t.file = n
+	t.typeCheck(n)
+	t.collectEnums(n)
+	t.collectValueClasses(n)
+	t.collectInterfaces(n)
+	t.analyzePointers(n)
+	t.analyzeMutability(n)
+	t.analyzeNames(n)
+	t.collectNullable(n)
 	t.analyzeFeatures(n)
+	t.collectComplex(n)
+	t.collectMonotonic(n)
+	t.collectSerializable(n)
 
 	t.writeLine("package " + n.Name.Name)
 	t.write("\n")
@@ -62,11 +97,15 @@ func (t *Transpiler) handleFile(tr *Transpiler, node ast.Node) error {
 	if t.usesChannels {
 		t.writeLine("import kotlinx.coroutines.channels.Channel")
 	}
+	if t.usesSerialization {
+		t.writeLine("import kotlinx.serialization.*")
+	}
+	importsAt := t.output.Len()
 
 	if len(n.Imports) > 0 {
 		for _, imp := range n.Imports {
 			path := strings.Trim(imp.Path.Value, "\"")
-			if path != "fmt" && path != "time" {
+			if !translatedImports[path] {
 				t.writeLine("import " + path)
 			}
 		}
@@ -74,9 +113,16 @@ func (t *Transpiler) handleFile(tr *Transpiler, node ast.Node) error {
 	}
 
 	for _, decl := range n.Decls {
+		before := t.output.Len()
 		t.Transpile(decl)
-		t.write("\n\n")
+		if t.output.Len() > before {
+			t.write("\n\n")
+		}
 	}
+
+	// Auxiliares emitidos apenas quando usados
+	t.writeRuntimeHelpers()
+	t.writeImports(importsAt, len(n.Imports) == 0)
 	return nil
 }
 
@@ -85,48 +131,67 @@ func (t *Transpiler) handleGenDecl(tr *Transpiler, node ast.Node) error {
 	if n.Tok == token.TYPE {
 		for _, spec := range n.Specs {
 			ts := spec.(*ast.TypeSpec)
+			if t.enums[ts.Name.Name] {
+				continue
+			}
+			if ts.Assign.IsValid() {
+				t.write("typealias " + t.ident(ts.Name) + " = " + t.resolveType(ts.Type))
+				continue
+			}
+			if t.valueClasses[ts.Name.Name] {
+				t.writeValueClass(ts)
+				continue
+			}
 			if st, ok := ts.Type.(*ast.StructType); ok {
-				def := StructDef{Fields: make(map[string]bool), Embeds: []string{}}
-				t.write("data class " + ts.Name.Name + "(")
-				if st.Fields != nil {
-					for i, field := range st.Fields.List {
-						if i > 0 { t.write(", ") }
-						typeStr := t.resolveType(field.Type)
-						if len(field.Names) == 0 {
-							fieldName := typeStr
-							if idx := strings.LastIndex(fieldName, "."); idx != -1 {
-								fieldName = fieldName[idx+1:]
-							}
-							t.write("var " + fieldName + ": " + typeStr)
-							def.Embeds = append(def.Embeds, fieldName)
-						} else {
-							for j, name := range field.Names {
-								if j > 0 { t.write(", ") }
-								t.write("var " + name.Name + ": " + typeStr)
-								def.Fields[name.Name] = true
-							}
-						}
-					}
-				}
-				t.write(")")
-				t.structs[ts.Name.Name] = def
+				t.writeStruct(ts, st)
+			} else if it, ok := ts.Type.(*ast.InterfaceType); ok && t.interfaces[ts.Name.Name] {
+				t.writeInterface(ts, it)
 			} else {
-				t.write("typealias " + ts.Name.Name + " = " + t.resolveType(ts.Type))
+				t.write("typealias " + t.ident(ts.Name) + " = " + t.resolveType(ts.Type))
 			}
 		}
 		return nil
 	}
 
+	if n.Tok == token.CONST && t.info != nil {
+		t.handleConstDecl(n)
+		return nil
+	}
+
 	if n.Tok == token.VAR || n.Tok == token.CONST {
 		keyword := "var"
 		if n.Tok == token.CONST { keyword = "val" }
+		first := true
 		for _, spec := range n.Specs {
 			vspec := spec.(*ast.ValueSpec)
+			if n.Tok == token.VAR && len(vspec.Names) > 1 && len(vspec.Values) == 1 {
+				// var a, b = f(): desestruturação, como em a, b := f()
+				if !first {
+					t.write("\n")
+					t.writeIndent()
+				}
+				first = false
+				targets := make([]ast.Expr, len(vspec.Names))
+				for i, name := range vspec.Names {
+					targets[i] = name
+				}
+				t.transpileDestructuring(targets, vspec.Values[0], true)
+				continue
+			}
 			typeName := ""
 			if vspec.Type != nil {
 				typeName = t.resolveType(vspec.Type)
+				// Interfaces podem receber nil (ex: var err error = f())
+				if typ := t.typeOf(vspec.Type); typ != nil && types.IsInterface(typ) {
+					typeName = nullableType(typeName, typ)
+				}
 			}
 			for i, name := range vspec.Names {
+				if !first {
+					t.write("\n")
+					t.writeIndent()
+				}
+				first = false
 				if len(vspec.Values) > i {
 					if comp, ok := vspec.Values[i].(*ast.CompositeLit); ok {
 						if ident, ok := comp.Type.(*ast.Ident); ok {
@@ -134,13 +199,40 @@ func (t *Transpiler) handleGenDecl(tr *Transpiler, node ast.Node) error {
 						}
 					}
 				}
-				t.write(keyword + " " + name.Name)
-				if typeName != "" {
-					t.write(": " + typeName)
+				if n.Tok == token.VAR {
+					keyword = t.declKeyword(name)
+				}
+				t.write(keyword + " " + t.ident(name))
+				obj := t.objectOf(name)
+				if t.isBoxed(name) {
+					// Variável com endereço tomado: guardada em uma caixa Ref
+					t.write(" = Ref<" + t.resolveGoType(obj.Type()) + ">(")
+					if i < len(vspec.Values) {
+						t.transpileTypedValue(vspec.Values[i], typeName)
+					} else {
+						t.write(t.zeroValue(obj.Type()))
+					}
+					t.write(")")
+					continue
 				}
 				if i < len(vspec.Values) {
+					if typeName != "" {
+						t.write(": " + typeName)
+					}
 					t.write(" = ")
 					t.transpileTypedValue(vspec.Values[i], typeName)
+					if t.needsCopy(vspec.Values[i]) {
+						t.write(t.copySuffix(t.typeOf(vspec.Values[i])))
+					}
+				} else if obj != nil {
+					// Sem inicializador: a variável recebe o valor zero do tipo Go
+					declType := typeName
+					if declType == "" {
+						declType = t.resolveGoType(obj.Type())
+					}
+					t.write(": " + nullableType(declType, obj.Type()) + " = " + t.zeroValue(obj.Type()))
+				} else if typeName != "" {
+					t.write(": " + typeName)
 				}
 			}
 		}
@@ -150,8 +242,15 @@ func (t *Transpiler) handleGenDecl(tr *Transpiler, node ast.Node) error {
 
 func (t *Transpiler) handleFuncDecl(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.FuncDecl)
+	// Métodos de value classes e de classes que implementam interfaces
+	// são emitidos dentro da própria classe
+	if t.memberTypes[receiverTypeName(n)] && t.memberOf == "" {
+		return nil
+	}
 	t.writeIndent()
-	if ast.IsExported(n.Name.Name) { t.write("public ") } else { t.write("internal ") }
+	if info := t.classes[t.memberOf]; info != nil && info.overrides[n.Name.Name] {
+		t.write("override ")
+	} else if ast.IsExported(n.Name.Name) { t.write("public ") } else { t.write("internal ") }
 	t.write("fun ")
 
 	if n.Type.TypeParams != nil {
@@ -160,30 +259,43 @@ func (t *Transpiler) handleFuncDecl(tr *Transpiler, node ast.Node) error {
 			if i > 0 { t.write(", ") }
 			for j, name := range field.Names {
 				if j > 0 { t.write(", ") }
-				t.write(name.Name)
+				t.write(t.ident(name))
 			}
 		}
 		t.write("> ")
 	}
 
-	recvParamName := ""
+	recvParamName, recvName := "", ""
 	recvTypeName := ""
 	if n.Recv != nil && len(n.Recv.List) > 0 {
-		recvTypeName = t.resolveType(n.Recv.List[0].Type)
-		t.write(recvTypeName + ".")
+		// Receivers ponteiro não são nulos: a extensão é sobre o tipo não anulável.
+		// O receiver ponteiro de uma value class é o próprio valor, sem Ref.
+		recvType := n.Recv.List[0].Type
+		if star, ok := recvType.(*ast.StarExpr); ok && t.valueClassOf(t.typeOf(star.X)) != nil {
+			recvType = star.X
+		}
+		recvTypeName = strings.TrimSuffix(t.resolveType(recvType), "?")
+		if t.memberOf == "" {
+			t.write(recvTypeName + ".")
+		}
 		if len(n.Recv.List[0].Names) > 0 {
 			recvParamName = n.Recv.List[0].Names[0].Name
+			recvName = t.ident(n.Recv.List[0].Names[0])
 			t.vars[recvParamName] = recvTypeName
 		}
 	}
 
-	t.write(n.Name.Name + "(")
+	mainArgs := n.Recv == nil && n.Name.Name == "main" && t.usesOSArgs()
+	t.write(t.ident(n.Name) + "(")
+	if mainArgs {
+		// os.Args recebe os argumentos do main do Kotlin, após o nome do programa
+		t.write("argv: Array<String>")
+	}
 	t.writeParams(n.Type.Params)
 	t.write(")")
 
 	if n.Type.Results != nil && len(n.Type.Results.List) > 0 {
-		retType := t.resolveType(n.Type.Results.List[0].Type)
-		t.write(": " + retType)
+		t.write(": " + t.resultsType(n.Type.Results))
 	}
 
 	if n.Name.Name == "main" {
@@ -196,46 +308,117 @@ func (t *Transpiler) handleFuncDecl(tr *Transpiler, node ast.Node) error {
 		t.write(" ")
 	}
 
+	var prelude []string
 	if recvParamName != "" && recvParamName != "_" {
-		t.write("{\n")
-		t.indent()
-		t.writeIndent()
-		t.write("val " + recvParamName + " = this\n")
-		for _, stmt := range n.Body.List {
-			t.writeIndent()
-			t.Transpile(stmt)
-			t.write("\n")
+		if _, isPtr := n.Recv.List[0].Type.(*ast.StarExpr); !isPtr && t.mutatesReceiver(n) {
+			// Receiver por valor que altera campos: trabalha sobre uma cópia
+			prelude = append(prelude, "val "+recvName+" = this"+t.copySuffix(t.typeOf(n.Recv.List[0].Type)))
+		} else if t.writesValueReceiver(n) {
+			// Value classes são imutáveis: *c = v altera só a cópia local
+			t.diagnose(n.Pos(), "método "+n.Name.Name+" altera o receiver ponteiro de uma value class; a alteração não chega ao chamador")
+			prelude = append(prelude, "var "+recvName+" = this")
+		} else {
+			prelude = append(prelude, "val "+recvName+" = this")
 		}
-		t.unindent()
-		t.writeIndent()
-		t.write("}")
-	} else {
-		t.Transpile(n.Body)
 	}
+	if mainArgs {
+		prelude = append(prelude, "os.Args.addAll(argv)")
+	}
+	prelude = append(prelude, t.paramPrelude(n.Type.Params, n.Body)...)
+	prelude = append(prelude, t.varargPrelude(n.Type.Params)...)
+
+	t.writeFuncBody(n.Type, n.Body, prelude)
 	return nil
 }
 
+// paramPrelude devolve as declarações que guardam em Ref os parâmetros cujo
+// endereço é tomado e copiam para var os parâmetros reatribuídos, já que
+// parâmetros são val no Kotlin. Structs e arrays alterados no corpo são
+// copiados, pois no Go o parâmetro é uma cópia do argumento.
+func (t *Transpiler) paramPrelude(fields *ast.FieldList, body *ast.BlockStmt) []string {
+	var lines []string
+	if fields == nil {
+		return lines
+	}
+	for _, field := range fields.List {
+		if _, variadic := field.Type.(*ast.Ellipsis); variadic {
+			// O vararg já é copiado para uma lista em varargPrelude
+			continue
+		}
+		for _, name := range field.Names {
+			value := t.ident(name)
+			if obj := t.objectOf(name); obj != nil && body != nil && t.copySuffix(obj.Type()) != "" && t.mutatesValue(body, obj) {
+				value += t.copySuffix(obj.Type())
+			}
+			if t.isBoxed(name) {
+				lines = append(lines, "val "+t.ident(name)+" = Ref("+value+")")
+			} else if t.declKeyword(name) == "var" && t.info != nil {
+				lines = append(lines, "var "+t.ident(name)+" = "+value)
+			} else if value != t.ident(name) {
+				lines = append(lines, "val "+t.ident(name)+" = "+value)
+			}
+		}
+	}
+	return lines
+}
+
 func (t *Transpiler) handleBlockStmt(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.BlockStmt)
 	t.write("{\n")
 	t.indent()
-	for _, stmt := range n.List {
-		t.writeIndent()
-		t.Transpile(stmt)
-		t.write("\n")
-	}
+	t.writeStatements(n.List)
 	t.unindent()
 	t.writeIndent()
 	t.write("}")
 	return nil
 }
 
+// hasSideEffects diz se avaliar a expressão chama funções ou recebe de canais
+func (t *Transpiler) hasSideEffects(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(node ast.Node) bool {
+		switch e := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if t.info == nil || !t.info.Types[e.Fun].IsType() {
+				found = true
+			}
+		case *ast.UnaryExpr:
+			if e.Op == token.ARROW {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
 func (t *Transpiler) handleAssignStmt(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.AssignStmt)
+	if n.Tok == token.ASSIGN && len(n.Lhs) == 1 && len(n.Rhs) == 1 {
+		if star, ok := n.Lhs[0].(*ast.StarExpr); ok && t.transpileStructStore(star, n.Rhs[0]) {
+			return nil
+		}
+	}
+	if op, ok := compoundOps[n.Tok]; ok && len(n.Lhs) == 1 && len(n.Rhs) == 1 {
+		t.transpileCompoundAssign(n.Lhs[0], op, n.Rhs[0])
+		return nil
+	}
+	if t.transpileCommaOk(n) || t.transpileMultiAssign(n) {
+		return nil
+	}
+	// _ = x só é mantido se x tiver efeitos colaterais
+	if id, ok := n.Lhs[0].(*ast.Ident); ok && id.Name == "_" && len(n.Lhs) == 1 && len(n.Rhs) == 1 {
+		if t.hasSideEffects(n.Rhs[0]) {
+			t.Transpile(n.Rhs[0])
+		}
+		return nil
+	}
 	for i, lhs := range n.Lhs {
 		if n.Tok == token.DEFINE {
-			t.write("var ")
 			if ident, ok := lhs.(*ast.Ident); ok {
+				t.write(t.declKeyword(ident) + " ")
 				if len(n.Rhs) > i {
 					if comp, ok := n.Rhs[i].(*ast.CompositeLit); ok {
 						if typeIdent, ok := comp.Type.(*ast.Ident); ok {
@@ -250,10 +433,10 @@ func (t *Transpiler) handleAssignStmt(tr *Transpiler, node ast.Node) error {
 				}
 			}
 		}
-		t.Transpile(lhs)
+		t.transpileTarget(lhs)
 		if i < len(n.Rhs) {
 			t.write(" = ")
-			t.Transpile(n.Rhs[i])
+			t.transpileAssignedValue(lhs, n.Rhs[i], n.Tok == token.DEFINE)
 		}
 	}
 	return nil
@@ -267,98 +450,22 @@ func (t *Transpiler) handleExprStmt(tr *Transpiler, node ast.Node) error {
 
 func (t *Transpiler) handleCallExpr(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.CallExpr)
-	if ident, ok := n.Fun.(*ast.Ident); ok {
-		if ident.Name == "make" {
-			if len(n.Args) > 0 {
-				if _, ok := n.Args[0].(*ast.MapType); ok {
-					ktType := t.resolveType(n.Args[0])
-					if strings.HasPrefix(ktType, "MutableMap") {
-						genericPart := strings.TrimPrefix(ktType, "MutableMap")
-						t.write("mutableMapOf" + genericPart + "()")
-						return nil
-					}
-				}
-				if ch, ok := n.Args[0].(*ast.ChanType); ok {
-					innerType := t.resolveType(ch.Value)
-					t.write("Channel<" + innerType + ">()")
-					return nil
-				}
-			}
-		}
-		if ident.Name == "append" && len(n.Args) >= 2 {
-			t.write("(")
-			t.Transpile(n.Args[0])
-			t.write(" + ")
-			t.Transpile(n.Args[1])
-			t.write(")")
-			return nil
-		}
+	if t.transpileSpreadCall(n) || t.transpileConversion(n) || t.transpileNew(n) || t.transpileBuiltin(n) || t.transpileFmt(n) || t.transpileStrings(n) ||
+		t.transpileMath(n) || t.transpileSort(n) || t.transpileErrors(n) || t.transpileIO(n) ||
+		t.transpileTime(n) || t.transpileJSON(n) {
+		return nil
 	}
 
-	isHandled := false
-	if sel, ok := n.Fun.(*ast.SelectorExpr); ok {
-		if x, ok := sel.X.(*ast.Ident); ok {
-			if x.Name == "time" && sel.Sel.Name == "Sleep" {
-				t.write("delay")
-				isHandled = true
-			}
-			if x.Name == "fmt" {
-				if sel.Sel.Name == "Printf" {
-					t.write("System.out.printf(")
-					for i, arg := range n.Args {
-						if i > 0 { t.write(", ") }
-						t.Transpile(arg)
-					}
-					t.write(")")
-					return nil
-				}
-
-				if strings.HasPrefix(sel.Sel.Name, "Print") {
-					cmd := "print"
-					if sel.Sel.Name == "Println" { cmd = "println" }
-					if len(n.Args) > 1 {
-						t.write(cmd + "(\"")
-						for i, arg := range n.Args {
-							if i > 0 { t.write(" ") }
-							t.write("${")
-							t.Transpile(arg)
-							t.write("}")
-						}
-						t.write("\")")
-						return nil
-					} else {
-						t.write(cmd)
-						isHandled = true
-					}
-				}
-
-				if strings.HasPrefix(sel.Sel.Name, "Scan") {
-					if len(n.Args) > 0 {
-						t.Transpile(n.Args[0])
-						t.write(" = readln()")
-						t.write(" // !! Converter tipo se necessario")
-						return nil
-					}
-				}
-			}
-		}
-	}
-	if !isHandled {
-		if _, isFuncLit := n.Fun.(*ast.FuncLit); isFuncLit {
-			t.write("(")
-			t.Transpile(n.Fun)
-			t.write(")")
-		} else {
-			t.Transpile(n.Fun)
-		}
+	if _, isFuncLit := n.Fun.(*ast.FuncLit); isFuncLit {
+		t.write("(")
+		t.Transpile(n.Fun)
+		t.write(")")
+	} else {
+		t.Transpile(n.Fun)
+		t.writeNonNull(n.Fun)
 	}
 
-	t.write("(")
-	for i, arg := range n.Args {
-		if i > 0 { t.write(", ") }
-		t.Transpile(arg)
-	}
-	t.write(")")
+	t.writeCallArgs(n)
 	return nil
 }
 
@@ -391,6 +498,9 @@ func (t *Transpiler) handleIfStmt(tr *Transpiler, node ast.Node) error {
 
 func (t *Transpiler) handleForStmt(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.ForStmt)
+	if t.transpileScanLoop(n) {
+		return nil
+	}
 	t.write("run {\n")
 	t.indent()
 	if n.Init != nil {
@@ -407,11 +517,7 @@ func (t *Transpiler) handleForStmt(tr *Transpiler, node ast.Node) error {
 	}
 	t.write(") {\n")
 	t.indent()
-	for _, stmt := range n.Body.List {
-		t.writeIndent()
-		t.Transpile(stmt)
-		t.write("\n")
-	}
+	t.writeStatements(n.Body.List)
 	if n.Post != nil {
 		t.writeIndent()
 		t.Transpile(n.Post)
@@ -428,19 +534,34 @@ func (t *Transpiler) handleForStmt(tr *Transpiler, node ast.Node) error {
 
 func (t *Transpiler) handleReturnStmt(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.ReturnStmt)
+	if t.transpileNamedReturn(n) {
+		return nil
+	}
+	if t.fn != nil && t.fn.label != "" {
+		t.write("return@" + t.fn.label)
+		return nil
+	}
 	t.write("return")
 	if len(n.Results) > 0 {
 		t.write(" ")
-		for i, res := range n.Results {
-			if i > 0 { t.write(", ") }
-			t.Transpile(res)
-		}
+		t.transpileReturnValues(n.Results)
 	}
 	return nil
 }
 
 func (t *Transpiler) handleIncDecStmt(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.IncDecStmt)
+	if t.isMapIndex(n.X) {
+		// m[k]++ lê o valor zero quando a chave não existe
+		op := token.ADD
+		if n.Tok == token.DEC {
+			op = token.SUB
+		}
+		one := &ast.BasicLit{ValuePos: n.TokPos, Kind: token.INT, Value: "1"}
+		t.info.Types[one] = types.TypeAndValue{Type: t.typeOf(n.X), Value: constant.MakeInt64(1)}
+		t.transpileCompoundAssign(n.X, op, one)
+		return nil
+	}
 	t.Transpile(n.X)
 	t.write(n.Tok.String())
 	return nil
@@ -448,8 +569,11 @@ func (t *Transpiler) handleIncDecStmt(tr *Transpiler, node ast.Node) error {
 
 func (t *Transpiler) handleBinaryExpr(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.BinaryExpr)
-	if t.hasComplex(n) {
-		t.write("/* Complex Logic */ null")
+	if t.transpileConstExpr(n) || t.transpileDurationOp(n) || t.transpileRuneOp(n, false) {
+		return nil
+	}
+	if plan, ok := t.planIntOp(n.Op, t.typeOf(n)); ok {
+		t.transpileIntOp(plan, n.X, n.Op, n.Y)
 	} else {
 		t.Transpile(n.X)
 		t.write(" " + n.Op.String() + " ")
@@ -468,13 +592,42 @@ func (t *Transpiler) handleParenExpr(tr *Transpiler, node ast.Node) error {
 
 func (t *Transpiler) handleIdent(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.Ident)
-	t.write(n.Name)
+	if t.transpileEnumConst(n) || t.transpileValueClassConst(n) {
+		return nil
+	}
+	if owner := t.enumConstOwner(n); owner != "" {
+		t.write(owner + ".")
+	}
+	if n.Name == "nil" {
+		if _, isNil := t.objectOf(n).(*types.Nil); isNil || t.info == nil {
+			t.write("null")
+			return nil
+		}
+	}
+	t.write(t.ident(n))
+	if t.info != nil && t.info.Uses[n] != nil && t.boxed[t.info.Uses[n]] {
+		t.write(".value")
+	}
 	return nil
 }
 
 func (t *Transpiler) handleBasicLit(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.BasicLit)
-	t.write(n.Value)
+	if t.transpileEnumConst(n) || t.transpileValueClassConst(n) {
+		return nil
+	}
+	switch n.Kind {
+	case token.STRING:
+		t.transpileStringLit(n)
+	case token.CHAR:
+		t.transpileCharLit(n)
+	case token.INT, token.FLOAT:
+		t.transpileNumberLit(n)
+	case token.IMAG:
+		t.transpileImagLit(n)
+	default:
+		t.write(n.Value)
+	}
 	return nil
 }
 
@@ -484,17 +637,18 @@ func (t *Transpiler) handleFuncLit(tr *Transpiler, node ast.Node) error {
 	t.writeParams(n.Type.Params)
 	t.write(")")
 	if n.Type.Results != nil && len(n.Type.Results.List) > 0 {
-		retType := t.resolveType(n.Type.Results.List[0].Type)
-		t.write(": " + retType)
+		t.write(": " + t.resultsType(n.Type.Results))
 	}
 	t.write(" ")
-	t.Transpile(n.Body)
+	prelude := append(t.paramPrelude(n.Type.Params, n.Body), t.varargPrelude(n.Type.Params)...)
+	t.writeFuncBody(n.Type, n.Body, prelude)
 	return nil
 }
 
 func (t *Transpiler) handleSendStmt(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.SendStmt)
 	t.Transpile(n.Chan)
+	t.writeNonNull(n.Chan)
 	t.write(".send(")
 	t.Transpile(n.Value)
 	t.write(")")
@@ -503,13 +657,49 @@ func (t *Transpiler) handleSendStmt(tr *Transpiler, node ast.Node) error {
 
 func (t *Transpiler) handleUnaryExpr(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.UnaryExpr)
+	if t.transpileConstExpr(n) {
+		return nil
+	}
 	switch n.Op.String() {
 	case "<-":
 		t.Transpile(n.X)
+		t.writeNonNull(n.X)
 		t.write(".receive()")
 	case "&":
+		// &x de uma variável em Ref compartilha a própria caixa
+		if id, ok := ast.Unparen(n.X).(*ast.Ident); ok && t.isBoxed(id) {
+			t.write(t.ident(id))
+			return nil
+		}
+		// Campos e elementos que não são structs não têm caixa: o ponteiro
+		// vira uma Ref com a cópia do valor atual
+		copied := ""
+		switch x := ast.Unparen(n.X).(type) {
+		case *ast.SelectorExpr:
+			if t.info == nil {
+				break
+			}
+			if s, ok := t.info.Selections[x]; ok && s.Kind() == types.FieldVal && !isStructType(s.Type()) {
+				copied = "endereço de campo não suportado: o ponteiro não acompanha alterações em " + x.Sel.Name
+			}
+		case *ast.IndexExpr:
+			if typ := t.typeOf(x); typ != nil && !isStructType(typ) {
+				copied = "endereço de elemento não suportado: o ponteiro guarda uma cópia e *p = v não altera o elemento"
+			}
+		}
+		if copied != "" {
+			t.diagnose(n.Pos(), copied)
+			t.useRuntime("Ref")
+			t.write("Ref(")
+			t.Transpile(n.X)
+			t.write(")")
+			return nil
+		}
 		t.Transpile(n.X)
 	default:
+		if t.transpileIntUnary(n) || t.transpileRuneUnary(n) {
+			return nil
+		}
 		t.write(n.Op.String())
 		t.Transpile(n.X)
 	}
@@ -518,14 +708,15 @@ func (t *Transpiler) handleUnaryExpr(tr *Transpiler, node ast.Node) error {
 
 func (t *Transpiler) handleGoStmt(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.GoStmt)
+	if call, ok := n.Call.Fun.(*ast.FuncLit); ok && containsDefer(call.Body) {
+		t.write("launch ")
+		t.writeFuncBody(call.Type, call.Body, nil)
+		return nil
+	}
 	t.write("launch {\n")
 	t.indent()
 	if call, ok := n.Call.Fun.(*ast.FuncLit); ok {
-		for _, stmt := range call.Body.List {
-			t.writeIndent()
-			t.Transpile(stmt)
-			t.write("\n")
-		}
+			t.writeStatements(call.Body.List)
 	} else {
 		t.writeIndent()
 		t.Transpile(n.Call)
@@ -539,9 +730,18 @@ func (t *Transpiler) handleGoStmt(tr *Transpiler, node ast.Node) error {
 
 func (t *Transpiler) handleTypeAssertExpr(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.TypeAssertExpr)
-	t.Transpile(n.X)
-	t.write(" as ")
-	t.write(t.resolveType(n.Type))
+	if n.Type == nil {
+		t.Transpile(n.X)
+		return nil
+	}
+	// Como no Go, a asserção de um só valor falha (ClassCastException) se o tipo não bater
+	ktType := t.resolveType(n.Type)
+	if typ := t.typeOf(n.Type); typ != nil {
+		ktType = t.resolveGoType(typ)
+	}
+	t.write("(")
+	t.transpileOperand(n.X)
+	t.write(" as " + strings.TrimSuffix(ktType, "?") + ")")
 	return nil
 }
 
@@ -551,15 +751,18 @@ func (t *Transpiler) handleRangeStmt(tr *Transpiler, node ast.Node) error {
 	key := "_"
 	if n.Key != nil {
 		if id, ok := n.Key.(*ast.Ident); ok {
-			key = id.Name
+			key = t.ident(id)
 		}
 	}
 	val := ""
 	if n.Value != nil {
 		if id, ok := n.Value.(*ast.Ident); ok {
-			val = id.Name
+			val = t.ident(id)
 		}
 	}
+	if t.transpileRangeIterable(n, key, val) {
+		return nil
+	}
 	if key != "_" && val != "" {
 		t.write("(" + key + ", " + val + ") in ")
 		t.Transpile(n.X)
@@ -626,11 +829,7 @@ func (t *Transpiler) handleCaseClause(tr *Transpiler, node ast.Node) error {
 	}
 	t.write("{\n")
 	t.indent()
-	for _, stmt := range n.Body {
-		t.writeIndent()
-		t.Transpile(stmt)
-		t.write("\n")
-	}
+	t.writeStatements(n.Body)
 	t.unindent()
 	t.writeIndent()
 	t.write("}")
@@ -639,21 +838,58 @@ func (t *Transpiler) handleCaseClause(tr *Transpiler, node ast.Node) error {
 
 func (t *Transpiler) handleIndexExpr(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.IndexExpr)
+	if t.isSortKeyElem(n) {
+		t.write("it")
+		return nil
+	}
+	if t.isMapIndex(n) {
+		t.transpileMapRead(n)
+		return nil
+	}
+	if t.transpileStringByte(n) {
+		return nil
+	}
 	t.Transpile(n.X)
+	t.writeNonNull(n.X)
 	t.write("[")
-	t.Transpile(n.Index)
+	// Índices de value class ou enum usam o valor (d.value), convertido para Int
+	switch typ := t.typeOf(n.Index); {
+	case t.hasValue(typ) && isIntType(typ):
+		t.transpileUnwrapped(n.Index, typ)
+	case t.info != nil && isIntegerType(typ):
+		t.writeIntOperand(n.Index, "Int", false)
+	default:
+		t.Transpile(n.Index)
+	}
 	t.write("]")
 	return nil
 }
 
 func (t *Transpiler) handleStarExpr(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.StarExpr)
+	if t.info != nil {
+		if tv, ok := t.info.Types[n]; ok && tv.IsType() {
+			t.write(t.resolveType(n))
+			return nil
+		}
+	}
+	elem := t.pointerElem(n.X)
 	t.Transpile(n.X)
+	if elem == nil || t.isValueReceiver(n.X) {
+		return nil
+	}
+	t.writeNonNull(n.X)
+	if !isStructType(elem) {
+		t.write(".value")
+	}
 	return nil
 }
 
 func (t *Transpiler) handleCompositeLit(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.CompositeLit)
+	if t.transpileTypedCompositeLit(n) {
+		return nil
+	}
 	switch n.Type.(type) {
 	case *ast.ArrayType:
 		t.write("mutableListOf")
@@ -682,15 +918,8 @@ func (t *Transpiler) handleKeyValueExpr(tr *Transpiler, node ast.Node) error {
 
 func (t *Transpiler) handleSelectorExpr(tr *Transpiler, node ast.Node) error {
 	n := node.(*ast.SelectorExpr)
-	if x, ok := n.X.(*ast.Ident); ok && x.Name == "time" {
-		switch n.Sel.Name {
-		case "Second":
-			t.write("1000L")
-			return nil
-		case "Millisecond":
-			t.write("1L")
-			return nil
-		}
+	if t.transpileSelection(n) || t.transpileMathConst(n) || t.transpileStdSelector(n) || t.transpileTimeSelector(n) {
+		return nil
 	}
 	varVarName := ""
 	if ident, ok := n.X.(*ast.Ident); ok {
@@ -711,7 +940,7 @@ func (t *Transpiler) handleSelectorExpr(tr *Transpiler, node ast.Node) error {
 	}
 	t.write(injectedEmbed)
 	t.write(".")
-	t.write(n.Sel.Name)
+	t.write(t.ident(n.Sel))
 	return nil
 }
 
@@ -741,10 +970,21 @@ func (t *Transpiler) analyzeFeatures(node ast.Node) {
 			}
 		case *ast.CallExpr:
 			if sel, ok := x.Fun.(*ast.SelectorExpr); ok {
-				if id, ok := sel.X.(*ast.Ident); ok && id.Name == "time" && sel.Sel.Name == "Sleep" {
-					t.usesCoroutines = true
+				if id, ok := sel.X.(*ast.Ident); ok && id.Name == "time" {
+					switch sel.Sel.Name {
+					case "Sleep":
+						t.usesCoroutines = true
+					case "After", "Tick", "NewTimer", "NewTicker":
+						// Temporizadores são canais alimentados por corrotinas
+						t.usesCoroutines = true
+						t.usesChannels = true
+					}
 				}
 			}
+			if id, ok := x.Fun.(*ast.Ident); ok && id.Name == "recover" {
+				// Com recover, os defers rodam em runDefers, que pode interromper o pânico
+				t.usesRecover = true
+			}
 			if id, ok := x.Fun.(*ast.Ident); ok && id.Name == "make" && len(x.Args) > 0 {
 				if _, ok := x.Args[0].(*ast.ChanType); ok {
 					t.usesChannels = true
@@ -760,32 +1000,45 @@ func (t *Transpiler) writeParams(fields *ast.FieldList) {
 	if fields != nil {
 		for i, field := range fields.List {
 			if i > 0 { t.write(", ") }
+			if ellipsis, ok := field.Type.(*ast.Ellipsis); ok {
+				name := "p" + strconv.Itoa(i)
+				if len(field.Names) > 0 {
+					name = t.ident(field.Names[0])
+				}
+				t.writeVarargParam(name, ellipsis)
+				continue
+			}
 			typeName := t.resolveType(field.Type)
+			if typ := t.typeOf(field.Type); typ != nil && types.Identical(typ, errorType) {
+				// error costuma receber nil (e sobrescreve Is(target GoError?) do runtime)
+				typeName = nullableType(typeName, typ)
+			}
+			if len(field.Names) == 0 {
+				// Parâmetro sem nome (comum em interfaces e tipos de função)
+				t.write("p" + strconv.Itoa(i) + ": " + typeName)
+			}
 			for j, name := range field.Names {
 				if j > 0 { t.write(", ") }
-				t.write(name.Name + ": " + typeName)
+				t.write(t.ident(name) + ": " + typeName)
 			}
 		}
 	}
 }
 
-func (t *Transpiler) hasComplex(node ast.Node) bool {
-	has := false
-	ast.Inspect(node, func(n ast.Node) bool {
-		if lit, ok := n.(*ast.BasicLit); ok && lit.Kind == token.IMAG {
-			has = true
-			return false
-		}
-		return true
-	})
-	return has
-}
-
 func (t *Transpiler) transpileTypedValue(expr ast.Expr, targetType string) {
+	if t.transpileValueClassConst(expr) {
+		return
+	}
+	if lit, ok := expr.(*ast.BasicLit); ok && t.info != nil {
+		if _, checked := t.info.Types[lit]; checked {
+			t.Transpile(lit)
+			return
+		}
+	}
 	if lit, ok := expr.(*ast.BasicLit); ok {
 		val := lit.Value
 		if lit.Kind == token.IMAG {
-			t.write("/* Complex not supported */ null")
+			t.transpileImagLit(lit)
 			return
 		}
 		if targetType == "Float" && !strings.HasSuffix(val, "f") {
@@ -813,9 +1066,5 @@ func (t *Transpiler) transpileTypedValue(expr ast.Expr, targetType string) {
 		t.write(val)
 		return
 	}
-	if t.hasComplex(expr) {
-		t.write("/* Complex Expression */ null")
-	} else {
-		t.Transpile(expr)
-	}
+	t.Transpile(expr)
 }
\ No newline at end of file
@@ -0,0 +1,462 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// scanFunc é a função do runtime que traduz uma função fmt.Scan*; os
+// destinos (&x) começam no argumento first e os anteriores a skip são descartados
+type scanFunc struct {
+	fn    string
+	skip  int
+	first int
+}
+
+var scanFuncs = map[string]scanFunc{
+	"Scan":    {"goScan", 0, 0},
+	"Scanln":  {"goScanln", 0, 0},
+	"Scanf":   {"goScanf", 0, 1},
+	"Sscan":   {"goSscan", 0, 1},
+	"Sscanf":  {"goSscanf", 0, 2},
+	"Fscan":   {"goScan", 1, 1},
+	"Fscanln": {"goScanln", 1, 1},
+	"Fscanf":  {"goScanf", 1, 2},
+}
+
+// osFuncs traduz as funções do pacote os para a JVM (mesma notação de stringsFuncs)
+var osFuncs = map[string]string{
+	"Exit":      "exitProcess(%0)",
+	"Getenv":    `(System.getenv(%0) ?: "")`,
+	"LookupEnv": `System.getenv(%0).let { Pair(it ?: "", it != null) }`,
+	"ReadFile":  "os.ReadFile(%0)",
+	"WriteFile": "os.WriteFile(%0, %1, %2)",
+}
+
+// writerMethods traduz os métodos de *bufio.Writer para BufferedWriter (%r é o receptor)
+var writerMethods = map[string]string{
+	"WriteString": "%r.write(%0)",
+	"Flush":       "%r.flush()",
+}
+
+// stdStreams são os arquivos padrão do os
+var stdStreams = map[string]string{
+	"Stdin":  "System.`in`",
+	"Stdout": "System.out",
+	"Stderr": "System.err",
+}
+
+// transpileIO traduz as chamadas de entrada e saída dos pacotes os, bufio e
+// fmt (Scan*): a entrada padrão é lida com readLine e os destinos de Scan
+// viram lambdas que convertem a palavra lida para o tipo da variável
+func (t *Transpiler) transpileIO(call *ast.CallExpr) bool {
+	if name := t.fmtCall(call); name != "" {
+		sf, ok := scanFuncs[name]
+		if !ok {
+			return false
+		}
+		return t.transpileScan(call, name, sf)
+	}
+	if name := t.pkgMember(call.Fun, "os"); name != "" {
+		tmpl, ok := osFuncs[name]
+		if !ok {
+			t.diagnose(call.Pos(), "os."+name+" sem tradução para Kotlin; chamada mantida")
+			return false
+		}
+		switch name {
+		case "Exit":
+			t.useImport("kotlin.system.exitProcess")
+		case "ReadFile", "WriteFile":
+			t.useRuntime("os")
+		}
+		t.writeCallTemplate(tmpl, nil, call.Args)
+		return true
+	}
+	if name := t.pkgMember(call.Fun, "bufio"); name != "" {
+		return t.transpileBufio(call, name)
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	switch t.bufioType(sel.X) {
+	case "Scanner":
+		return t.transpileScannerMethod(call, sel)
+	case "Reader":
+		if sel.Sel.Name == "ReadString" && len(call.Args) == 1 {
+			if val, ok := t.constValue(call.Args[0]); ok && constant.Compare(val, token.EQL, constant.MakeInt64('\n')) {
+				// O Go devolve a linha com o \n; no fim da entrada, io.EOF
+				t.useRuntime("io")
+				t.write(`readLine().let { if (it == null) Pair("", io.EOF) else Pair(it + "\n", null) }`)
+				return true
+			}
+		}
+		t.diagnose(call.Pos(), "bufio.Reader."+sel.Sel.Name+" sem tradução para Kotlin; chamada mantida")
+	case "Writer":
+		if tmpl, ok := writerMethods[sel.Sel.Name]; ok {
+			t.writeCallTemplate(tmpl, sel.X, call.Args)
+			return true
+		}
+		t.diagnose(call.Pos(), "bufio.Writer."+sel.Sel.Name+" sem tradução para Kotlin; chamada mantida")
+	}
+	return false
+}
+
+// transpileBufio traduz os construtores do bufio. Apenas a entrada padrão
+// (e strings.NewReader, no Scanner) e as saídas padrão são suportadas.
+func (t *Transpiler) transpileBufio(call *ast.CallExpr, name string) bool {
+	if len(call.Args) != 1 {
+		return false
+	}
+	src := t.stdStream(call.Args[0])
+	switch {
+	case name == "NewScanner" && src == "Stdin":
+		t.write("generateSequence(::readLine)")
+		return true
+	case name == "NewScanner":
+		if inner, ok := ast.Unparen(call.Args[0]).(*ast.CallExpr); ok && t.pkgMember(inner.Fun, "strings") == "NewReader" && len(inner.Args) == 1 {
+			t.writeCallTemplate("%0.lineSequence()", nil, inner.Args)
+			return true
+		}
+	case name == "NewReader" && src == "Stdin":
+		t.write(stdStreams[src])
+		return true
+	case name == "NewWriter" && (src == "Stdout" || src == "Stderr"):
+		t.write(stdStreams[src] + ".bufferedWriter()")
+		return true
+	}
+	t.diagnose(call.Pos(), "bufio."+name+" só é traduzido para a entrada e as saídas padrão; chamada mantida")
+	return false
+}
+
+// transpileScannerMethod traduz os métodos de *bufio.Scanner usados dentro
+// de um laço for scanner.Scan() (ver transpileScanLoop)
+func (t *Transpiler) transpileScannerMethod(call *ast.CallExpr, sel *ast.SelectorExpr) bool {
+	id, _ := ast.Unparen(sel.X).(*ast.Ident)
+	switch sel.Sel.Name {
+	case "Text":
+		if id != nil && t.scanLines[t.objectOf(id)] != "" {
+			t.write(t.scanLines[t.objectOf(id)])
+			return true
+		}
+	case "Err":
+		// readLine não falha: o fim da entrada apenas encerra a sequência
+		t.write("null")
+		return true
+	case "Split":
+		// O modo de leitura é aplicado no laço for scanner.Scan()
+		if split := t.scannerSplit(id); id != nil && split != "" {
+			t.write("// " + t.ident(id) + ".Split(bufio." + split + "): aplicado no laço de leitura")
+			return true
+		}
+	}
+	t.diagnose(call.Pos(), "bufio.Scanner."+sel.Sel.Name+" só é traduzido em for scanner.Scan() { scanner.Text() }; chamada mantida")
+	return false
+}
+
+// transpileScanLoop traduz for scanner.Scan() { ... } para um for sobre a
+// sequência de linhas. Se o corpo começa com line := scanner.Text(), line vira
+// a variável do laço; senão as chamadas a Text() usam <scanner>Line.
+func (t *Transpiler) transpileScanLoop(n *ast.ForStmt) bool {
+	if n.Init != nil || n.Post != nil || n.Cond == nil {
+		return false
+	}
+	call, ok := ast.Unparen(n.Cond).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Scan" || t.bufioType(sel.X) != "Scanner" {
+		return false
+	}
+	scanner, ok := ast.Unparen(sel.X).(*ast.Ident)
+	if !ok || t.objectOf(scanner) == nil {
+		return false
+	}
+	body := n.Body.List
+	name := strings.Trim(t.ident(scanner), "`") + "Line"
+	if len(body) > 0 {
+		if line, ok := t.scanTextDef(body[0], scanner); ok {
+			name = t.ident(line)
+			body = body[1:]
+		}
+	}
+	obj := t.objectOf(scanner)
+	t.scanLines[obj] = name
+	defer delete(t.scanLines, obj)
+	t.write("for (" + name + " in ")
+	t.Transpile(scanner)
+	if t.scannerSplit(scanner) == "ScanWords" {
+		t.write(`.flatMap { it.split(Regex("\\s+")) }.filter { it.isNotEmpty() }`)
+	}
+	t.write(") {\n")
+	t.indent()
+	for _, stmt := range body {
+		t.writeIndent()
+		t.Transpile(stmt)
+		t.write("\n")
+	}
+	t.unindent()
+	t.writeIndent()
+	t.write("}")
+	return true
+}
+
+// scannerSplit devolve o modo de leitura (ScanLines ou ScanWords) definido
+// com scanner.Split no arquivo, ou "" se não houver Split suportado
+func (t *Transpiler) scannerSplit(scanner *ast.Ident) string {
+	if scanner == nil || t.file == nil {
+		return ""
+	}
+	obj := t.objectOf(scanner)
+	if obj == nil {
+		return ""
+	}
+	split := ""
+	ast.Inspect(t.file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return split == ""
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Split" {
+			return true
+		}
+		if x, ok := ast.Unparen(sel.X).(*ast.Ident); ok && t.objectOf(x) == obj {
+			switch mode := t.pkgMember(call.Args[0], "bufio"); mode {
+			case "ScanLines", "ScanWords":
+				split = mode
+			}
+		}
+		return split == ""
+	})
+	return split
+}
+
+// scanTextDef reconhece line := scanner.Text() com line nunca reatribuída
+func (t *Transpiler) scanTextDef(stmt ast.Stmt, scanner *ast.Ident) (*ast.Ident, bool) {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, false
+	}
+	line, ok := assign.Lhs[0].(*ast.Ident)
+	call, isCall := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+	if !ok || !isCall || len(call.Args) != 0 || line.Name == "_" {
+		return nil, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Text" {
+		return nil, false
+	}
+	x, ok := ast.Unparen(sel.X).(*ast.Ident)
+	obj := t.info.Defs[line]
+	if !ok || obj == nil || t.objectOf(x) != t.objectOf(scanner) || t.reassigned[obj] || t.boxed[obj] {
+		return nil, false
+	}
+	return line, true
+}
+
+// transpileScan traduz fmt.Scan*, fmt.Sscan* e fmt.Fscan* (estes apenas da
+// entrada padrão): fmt.Scan(&a, &b) vira goScan({ a = it.toInt() }, { b = it })
+func (t *Transpiler) transpileScan(call *ast.CallExpr, name string, sf scanFunc) bool {
+	if len(call.Args) < sf.first {
+		return false
+	}
+	if sf.skip > 0 && t.stdStream(call.Args[0]) != "Stdin" && t.bufioType(call.Args[0]) != "Reader" {
+		t.diagnose(call.Pos(), "fmt."+name+" só é traduzido para a entrada padrão; chamada mantida")
+		return false
+	}
+	var targets []string
+	for _, arg := range call.Args[sf.first:] {
+		conv, ok := t.scanConversion(t.pointerElem(arg))
+		if !ok {
+			t.diagnose(arg.Pos(), "fmt."+name+": destino sem conversão de texto para Kotlin; chamada mantida")
+			return false
+		}
+		targets = append(targets, conv)
+	}
+	t.useRuntime(sf.fn)
+	t.write(sf.fn + "(")
+	for i, arg := range call.Args[sf.skip:sf.first] {
+		if i > 0 {
+			t.write(", ")
+		}
+		t.Transpile(arg)
+	}
+	for i, arg := range call.Args[sf.first:] {
+		if i > 0 || sf.first > sf.skip {
+			t.write(", ")
+		}
+		t.write("{ ")
+		if addr, ok := ast.Unparen(arg).(*ast.UnaryExpr); ok && addr.Op == token.AND {
+			t.Transpile(addr.X)
+		} else {
+			t.Transpile(arg)
+			t.writeNonNull(arg)
+			t.write(".value")
+		}
+		t.write(" = " + targets[i] + " }")
+	}
+	t.write(")")
+	return true
+}
+
+// scanConversion devolve a expressão Kotlin que converte a palavra lida (it)
+// para o tipo do destino de um Scan: strings, booleanos, números e value classes
+func (t *Transpiler) scanConversion(typ types.Type) (string, bool) {
+	if typ == nil {
+		return "", false
+	}
+	if vc := t.valueClassOf(typ); vc != nil {
+		inner, ok := t.scanConversion(vc.Underlying())
+		return t.resolveGoType(vc) + "(" + inner + ")", ok
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok || basic.Info()&(types.IsString|types.IsBoolean|types.IsInteger|types.IsFloat) == 0 {
+		return "", false
+	}
+	switch kt := kotlinBasicType(basic, nil); kt {
+	case "String":
+		return "it", true
+	case "Boolean":
+		return "it.toBooleanStrict()", true
+	case "Char":
+		return "it.toInt().toChar()", true
+	default:
+		return "it.to" + kt + "()", true
+	}
+}
+
+// transpileStdSelector traduz os.Args, os.Stdin/Stdout/Stderr, os.ErrNotExist e io.EOF
+func (t *Transpiler) transpileStdSelector(sel *ast.SelectorExpr) bool {
+	if name := t.pkgMember(sel, "os"); name != "" {
+		if stream, ok := stdStreams[name]; ok {
+			t.write(stream)
+			return true
+		}
+		if name == "Args" || name == "ErrNotExist" {
+			t.useRuntime("os")
+			t.write("os." + name)
+			return true
+		}
+	}
+	if t.pkgMember(sel, "io") == "EOF" {
+		t.useRuntime("io")
+		t.write("io.EOF")
+		return true
+	}
+	return false
+}
+
+// stdStream devolve Stdin, Stdout ou Stderr se expr é o arquivo padrão do os
+func (t *Transpiler) stdStream(expr ast.Expr) string {
+	name := t.pkgMember(ast.Unparen(expr), "os")
+	if _, ok := stdStreams[name]; ok {
+		return name
+	}
+	return ""
+}
+
+// bufioType devolve o nome do tipo do bufio (Scanner, Reader, Writer) de expr
+func (t *Transpiler) bufioType(expr ast.Expr) string {
+	typ := t.typeOf(expr)
+	if typ == nil {
+		return ""
+	}
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "bufio" {
+		return ""
+	}
+	return named.Obj().Name()
+}
+
+// stringsBuilder devolve o StringBuilder escrito por fmt.Fprint*: sb em &sb
+// ou o próprio ponteiro *strings.Builder
+func (t *Transpiler) stringsBuilder(w ast.Expr) ast.Expr {
+	if addr, ok := ast.Unparen(w).(*ast.UnaryExpr); ok && addr.Op == token.AND {
+		if isBuilderType(t.typeOf(addr.X)) {
+			return addr.X
+		}
+		return nil
+	}
+	if ptr, ok := t.typeOf(w).(*types.Pointer); ok && isBuilderType(ptr.Elem()) {
+		return w
+	}
+	return nil
+}
+
+// isBuilderType informa se o tipo é strings.Builder
+func isBuilderType(typ types.Type) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "strings" && named.Obj().Name() == "Builder"
+}
+
+// usesOSArgs informa se o arquivo usa os.Args, que o main preenche com seus argumentos
+func (t *Transpiler) usesOSArgs() bool {
+	if t.info == nil {
+		return false
+	}
+	for _, obj := range t.info.Uses {
+		if v, ok := obj.(*types.Var); ok && v.Pkg() != nil && v.Pkg().Path() == "os" && v.Name() == "Args" {
+			return true
+		}
+	}
+	return false
+}
+
+// transpileFprint traduz fmt.Fprint* para as saídas padrão, *bufio.Writer e
+// *strings.Builder (StringBuilder, em que o texto vai para append)
+func (t *Transpiler) transpileFprint(call *ast.CallExpr, name string) bool {
+	if len(call.Args) == 0 || (name == "Fprintf" && len(call.Args) < 2) {
+		return false
+	}
+	w, args := call.Args[0], call.Args[1:]
+	builder := t.stringsBuilder(w)
+	writer := t.bufioType(w) == "Writer" || builder != nil
+	switch {
+	case t.stdStream(w) == "Stdout":
+		t.write("print")
+	case t.stdStream(w) == "Stderr":
+		t.write("System.err.print")
+	case builder != nil:
+		t.transpileOperand(builder)
+		t.writeNonNull(builder)
+		t.write(".append")
+	case writer:
+		t.transpileOperand(w)
+		t.writeNonNull(w)
+		t.write(".write")
+	default:
+		t.diagnose(call.Pos(), "fmt."+name+" só é traduzido para as saídas padrão, bufio.Writer e strings.Builder; chamada mantida")
+		return false
+	}
+	if name == "Fprintln" && !writer {
+		t.write("ln")
+	}
+	t.write("(")
+	switch {
+	case name == "Fprintf":
+		t.transpileFormat(args[0], args[1:])
+	case name == "Fprintln" && writer:
+		// BufferedWriter não tem println: o \n vai no próprio texto
+		if len(args) == 0 {
+			t.write(`"\n"`)
+		} else {
+			t.writeTemplate(args, alwaysSpaced, `\n`)
+		}
+	case name == "Fprintln":
+		if len(args) > 0 {
+			t.writeTemplate(args, alwaysSpaced, "")
+		}
+	default:
+		t.writeTemplate(args, t.printSpaced, "")
+	}
+	t.write(")")
+	return true
+}
@@ -0,0 +1,298 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonFuncs são as funções do encoding/json oferecidas pelo objeto json do runtime
+var jsonFuncs = map[string]bool{
+	"Marshal":       true,
+	"MarshalIndent": true,
+}
+
+// jsonTag é a tag json:"nome,opções" de um campo de struct
+type jsonTag struct {
+	name      string
+	omitEmpty bool
+	skip      bool
+	quoted    bool
+}
+
+// jsonQuoted associa os tipos Kotlin aceitos pela opção ,string aos
+// serializers do runtime que escrevem o valor entre aspas
+var jsonQuoted = map[string]string{
+	"Int":     "GoJsonQuoted.IntValue",
+	"Long":    "GoJsonQuoted.LongValue",
+	"Double":  "GoJsonQuoted.DoubleValue",
+	"Boolean": "GoJsonQuoted.BooleanValue",
+}
+
+// parseJSONTag lê a tag json de um campo; ok é false se o campo não tem tag json
+func parseJSONTag(field *ast.Field) (jsonTag, bool) {
+	if field.Tag == nil {
+		return jsonTag{}, false
+	}
+	raw, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return jsonTag{}, false
+	}
+	value, ok := reflect.StructTag(raw).Lookup("json")
+	if !ok {
+		return jsonTag{}, false
+	}
+	name, opts, _ := strings.Cut(value, ",")
+	tag := jsonTag{name: name, skip: name == "-" && opts == ""}
+	for _, opt := range strings.Split(opts, ",") {
+		switch opt {
+		case "omitempty":
+			tag.omitEmpty = true
+		case "string":
+			tag.quoted = true
+		}
+	}
+	return tag, true
+}
+
+// collectSerializable marca como @Serializable as structs com tags json e os
+// tipos do pacote passados ao encoding/json, junto com os tipos dos seus campos
+func (t *Transpiler) collectSerializable(file *ast.File) {
+	seen := make(map[types.Type]bool)
+	var mark func(typ types.Type)
+	mark = func(typ types.Type) {
+		if typ == nil || seen[typ] {
+			return
+		}
+		seen[typ] = true
+		switch u := types.Unalias(typ).(type) {
+		case *types.Pointer:
+			mark(u.Elem())
+		case *types.Slice:
+			mark(u.Elem())
+		case *types.Array:
+			mark(u.Elem())
+		case *types.Map:
+			mark(u.Key())
+			mark(u.Elem())
+		case *types.Named:
+			obj := u.Obj()
+			if obj.Pkg() == nil || obj.Pkg() != t.pkg || t.enums[obj.Name()] {
+				return
+			}
+			if st, ok := u.Underlying().(*types.Struct); ok {
+				t.serializable[obj.Name()] = true
+				for i := 0; i < st.NumFields(); i++ {
+					mark(st.Field(i).Type())
+				}
+			} else if t.valueClasses[obj.Name()] {
+				t.serializable[obj.Name()] = true
+			}
+		}
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.TypeSpec:
+			st, ok := n.Type.(*ast.StructType)
+			if !ok || st.Fields == nil {
+				return true
+			}
+			for _, field := range st.Fields.List {
+				if _, tagged := parseJSONTag(field); tagged {
+					if obj := t.objectOf(n.Name); obj != nil {
+						mark(obj.Type())
+					}
+					break
+				}
+			}
+		case *ast.CallExpr:
+			if arg := t.jsonValueArg(n); arg != nil {
+				mark(t.typeOf(arg))
+			}
+		}
+		return true
+	})
+	if len(t.serializable) > 0 {
+		t.usesSerialization = true
+	}
+}
+
+// jsonValueArg devolve o valor serializado ou o destino de uma chamada ao encoding/json
+func (t *Transpiler) jsonValueArg(call *ast.CallExpr) ast.Expr {
+	switch name := t.pkgMember(call.Fun, "encoding/json"); {
+	case jsonFuncs[name] && len(call.Args) > 0:
+		return call.Args[0]
+	case name == "Unmarshal" && len(call.Args) == 2:
+		return call.Args[1]
+	}
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && len(call.Args) == 1 {
+		switch t.jsonType(sel.X) + "." + sel.Sel.Name {
+		case "Encoder.Encode", "Decoder.Decode":
+			return call.Args[0]
+		}
+	}
+	return nil
+}
+
+// jsonType devolve o nome do tipo do encoding/json (Encoder, Decoder) de uma expressão
+func (t *Transpiler) jsonType(expr ast.Expr) string {
+	typ := t.typeOf(expr)
+	if typ == nil {
+		return ""
+	}
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "encoding/json" {
+		return ""
+	}
+	return named.Obj().Name()
+}
+
+// writeSerializable anota a declaração de uma struct serializável; omitempty
+// usa @EncodeDefault, que ainda é experimental no kotlinx.serialization
+func (t *Transpiler) writeSerializable(st *ast.StructType) {
+	t.writeLine("@Serializable")
+	if st.Fields != nil {
+		for _, field := range st.Fields.List {
+			if tag, _ := parseJSONTag(field); tag.omitEmpty {
+				t.writeLine("@OptIn(ExperimentalSerializationApi::class)")
+				break
+			}
+		}
+	}
+	t.writeIndent()
+}
+
+// jsonAnnotations devolve as anotações de um campo de struct serializável:
+// @SerialName para a chave da tag, @EncodeDefault(NEVER) para omitempty (o
+// valor padrão é o zero do Go) e @Transient para json:"-" e campos não
+// exportados, que o encoding/json ignora
+func (t *Transpiler) jsonAnnotations(field *ast.Field, f *types.Var, name string) string {
+	tag, _ := parseJSONTag(field)
+	if tag.skip || (f != nil && !f.Exported()) {
+		return "@Transient "
+	}
+	if f != nil {
+		if f.Embedded() {
+			t.diagnose(field.Pos(), "campo embutido "+f.Name()+" serializado como objeto aninhado, e não achatado como no encoding/json")
+		}
+		if types.IsInterface(f.Type()) {
+			t.diagnose(field.Pos(), "campo "+f.Name()+" de tipo interface não é serializável pelo kotlinx.serialization")
+		}
+	}
+	annotations := ""
+	if tag.name != "" && tag.name != strings.Trim(name, "`") {
+		annotations += "@SerialName(" + strconv.Quote(tag.name) + ") "
+	}
+	if tag.omitEmpty {
+		annotations += "@EncodeDefault(EncodeDefault.Mode.NEVER) "
+	}
+	if tag.quoted && f != nil {
+		if serializer, ok := jsonQuoted[t.resolveGoType(f.Type())]; ok {
+			t.useRuntime("GoJsonQuoted")
+			annotations += "@Serializable(with = " + serializer + "::class) "
+		} else {
+			t.diagnose(field.Pos(), "opção ,string da tag json sem tradução para o tipo de "+f.Name()+"; valor serializado sem aspas")
+		}
+	}
+	return annotations
+}
+
+// transpileJSON traduz as chamadas ao encoding/json para o objeto json do
+// runtime, que usa Json.encodeToString e Json.decodeFromString. Os destinos
+// &v de Unmarshal e Decode viram lambdas { v = it }, como em errors.As.
+func (t *Transpiler) transpileJSON(call *ast.CallExpr) bool {
+	if name := t.pkgMember(call.Fun, "encoding/json"); name != "" {
+		switch {
+		case jsonFuncs[name]:
+			t.useRuntime("json")
+			t.write("json." + name)
+			t.writeCallArgs(call)
+			return true
+		case name == "Unmarshal" && len(call.Args) == 2:
+			t.useRuntime("json")
+			return t.writeJSONDecode(call, nil, "json.Unmarshal", call.Args[0], call.Args[1])
+		case name == "NewEncoder" && len(call.Args) == 1:
+			t.useRuntime("GoJsonEncoder")
+			t.write("GoJsonEncoder")
+			t.writeCallArgs(call)
+			return true
+		case name == "NewDecoder" && len(call.Args) == 1:
+			t.useRuntime("GoJsonDecoder")
+			t.write("GoJsonDecoder")
+			t.writeCallArgs(call)
+			return true
+		}
+		t.diagnose(call.Pos(), "json."+name+" sem tradução para Kotlin; chamada mantida")
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || t.jsonType(sel.X) != "Decoder" || sel.Sel.Name != "Decode" || len(call.Args) != 1 {
+		return false
+	}
+	return t.writeJSONDecode(call, sel.X, "Decode", nil, call.Args[0])
+}
+
+// writeJSONDecode escreve recv.fn<T>(data) { v = it } para o destino &v
+func (t *Transpiler) writeJSONDecode(call *ast.CallExpr, recv ast.Expr, fn string, data, dest ast.Expr) bool {
+	addr, ok := ast.Unparen(dest).(*ast.UnaryExpr)
+	if !ok || addr.Op != token.AND {
+		t.diagnose(call.Pos(), fn+" sem &variável como destino; chamada mantida")
+		return false
+	}
+	typ := t.typeOf(addr.X)
+	if typ == nil {
+		return false
+	}
+	if recv != nil {
+		t.Transpile(recv)
+		t.write(".")
+	}
+	ktType := strings.TrimSuffix(t.resolveGoType(typ), "?")
+	dynamic := hasInterfaceValues(typ)
+	if dynamic {
+		// Valores interface{} não têm serializer: o runtime monta os maps e
+		// listas com float64, string, bool e nil, como o encoding/json
+		t.write(fn + "Any")
+	} else {
+		t.write(fn + "<" + ktType + ">")
+	}
+	if data != nil {
+		t.write("(")
+		t.Transpile(data)
+		t.write(")")
+	}
+	t.write(" { ")
+	t.Transpile(addr.X)
+	if dynamic && !types.IsInterface(typ) {
+		t.write(" = it as " + ktType + " }")
+	} else {
+		t.write(" = it }")
+	}
+	return true
+}
+
+// hasInterfaceValues informa se o destino é interface{} ou um map, slice ou
+// array cujos elementos são interface{}, como map[string]interface{}
+func hasInterfaceValues(typ types.Type) bool {
+	switch u := types.Unalias(typ).(type) {
+	case *types.Interface:
+		return true
+	case *types.Named:
+		_, isStruct := u.Underlying().(*types.Struct)
+		return !isStruct && hasInterfaceValues(u.Underlying())
+	case *types.Map:
+		return hasInterfaceValues(u.Elem())
+	case *types.Slice:
+		return hasInterfaceValues(u.Elem())
+	case *types.Array:
+		return hasInterfaceValues(u.Elem())
+	}
+	return false
+}
@@ -0,0 +1,253 @@
+package transpiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// transpileStringLit decodifica um literal de string do Go e o reescreve como
+// literal Kotlin: strings interpretadas viram "..." e strings cruas viram """..."""
+func (t *Transpiler) transpileStringLit(n *ast.BasicLit) {
+	value, err := strconv.Unquote(n.Value)
+	if err != nil {
+		t.write(n.Value)
+		return
+	}
+	if !utf8.ValidString(value) {
+		// Strings do Kotlin são UTF-16: cada byte inválido vira U+FFFD, como no range do Go
+		t.diagnose(n.Pos(), "string com UTF-8 inválido: os bytes inválidos viraram U+FFFD")
+		value = string([]rune(value))
+	}
+	if strings.HasPrefix(n.Value, "`") {
+		t.write(quoteKotlinRawString(value))
+		return
+	}
+	t.write(quoteKotlinString(value))
+}
+
+// transpileCharLit traduz um literal de runa. Quando o contexto espera um
+// número (ex: var b byte = 'a'), escreve o valor numérico correspondente.
+func (t *Transpiler) transpileCharLit(n *ast.BasicLit) {
+	value, _, _, err := strconv.UnquoteChar(n.Value[1:len(n.Value)-1], '\'')
+	if err != nil {
+		t.write(n.Value)
+		return
+	}
+	if t.info != nil {
+		if tv, ok := t.info.Types[n]; ok && tv.Value != nil {
+			if b, ok := tv.Type.Underlying().(*types.Basic); ok && kotlinBasicType(b, tv.Value) != "Char" {
+				if literal, ok := t.constLiteral(tv.Value, tv.Type); ok {
+					t.write(literal)
+					return
+				}
+			}
+		}
+	}
+	if value > 0xFFFF {
+		// Char do Kotlin é UTF-16: a runa não cabe, escreve o code point
+		t.diagnose(n.Pos(), fmt.Sprintf("runa U+%04X fora do BMP não cabe em Char; usando o code point inteiro", value))
+		t.write(fmt.Sprintf("0x%X /* U+%04X */", value, value))
+		return
+	}
+	t.write(quoteKotlinChar(value))
+}
+
+// quoteKotlinString gera um literal de string Kotlin válido para o texto
+// informado, escapando '$' para que não vire um template
+func quoteKotlinString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		b.WriteString(escapeKotlinRune(r, '"'))
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// quoteKotlinRawString gera uma raw string Kotlin ("""..."""). Nela não há
+// escapes: '$' e sequências de três aspas são inseridos via template.
+func quoteKotlinRawString(s string) string {
+	var b strings.Builder
+	b.WriteString(`"""`)
+	quotes := 0
+	flushQuotes := func(last bool) {
+		// Aspas no fim do conteúdo se confundiriam com o fechamento
+		if quotes >= 3 || (last && quotes > 0) {
+			b.WriteString(`${"` + strings.Repeat(`\"`, quotes) + `"}`)
+		} else {
+			b.WriteString(strings.Repeat(`"`, quotes))
+		}
+		quotes = 0
+	}
+	for _, r := range s {
+		if r == '"' {
+			quotes++
+			continue
+		}
+		flushQuotes(false)
+		if r == '$' {
+			b.WriteString("${'$'}")
+			continue
+		}
+		b.WriteRune(r)
+	}
+	flushQuotes(true)
+	b.WriteString(`"""`)
+	return b.String()
+}
+
+// quoteKotlinChar gera um literal de Char Kotlin para a runa informada
+func quoteKotlinChar(r rune) string {
+	return "'" + escapeKotlinRune(r, '\'') + "'"
+}
+
+// escapeKotlinRune devolve a forma escapada de uma runa dentro de um literal
+// delimitado por quote. Kotlin só aceita os escapes \t \b \n \r \' \" \\ \$
+// e \uXXXX; o restante dos caracteres não imprimíveis usa \uXXXX (ou um par
+// de surrogates fora do BMP).
+func escapeKotlinRune(r rune, quote rune) string {
+	switch r {
+	case '\\':
+		return `\\`
+	case '\n':
+		return `\n`
+	case '\t':
+		return `\t`
+	case '\r':
+		return `\r`
+	case '\b':
+		return `\b`
+	case '$':
+		if quote == '"' {
+			return `\$`
+		}
+		return "$"
+	case quote:
+		return `\` + string(quote)
+	}
+	if unicode.IsPrint(r) {
+		return string(r)
+	}
+	if r > 0xFFFF {
+		r1, r2 := utf16Surrogates(r)
+		return fmt.Sprintf(`\u%04X\u%04X`, r1, r2)
+	}
+	return fmt.Sprintf(`\u%04X`, r)
+}
+
+// utf16Surrogates divide uma runa fora do BMP no par de surrogates UTF-16
+func utf16Surrogates(r rune) (rune, rune) {
+	r -= 0x10000
+	return 0xD800 + (r>>10)&0x3FF, 0xDC00 + r&0x3FF
+}
+
+// transpileNumberLit traduz um literal numérico para a forma aceita pelo
+// Kotlin, com o sufixo do tipo checado (L, u, uL, f). Formas sem equivalente
+// (octal, floats hexadecimais) são reescritas a partir do valor constante.
+func (t *Transpiler) transpileNumberLit(n *ast.BasicLit) {
+	lexical, ok := kotlinNumberLit(n.Value, n.Kind)
+	if t.info == nil {
+		if ok {
+			t.write(lexical)
+		} else {
+			t.write(n.Value)
+		}
+		return
+	}
+	tv, checked := t.info.Types[n]
+	if !checked || tv.Value == nil {
+		t.write(n.Value)
+		return
+	}
+	// Literais comparados a durações (d > 0) são durações constantes
+	if isTimeType(tv.Type, "Duration") {
+		if literal, ok := t.durationLiteral(tv.Value); ok {
+			t.write(literal)
+			return
+		}
+	}
+	basic, isBasic := tv.Type.Underlying().(*types.Basic)
+	if !isBasic {
+		t.write(n.Value)
+		return
+	}
+	kt := kotlinBasicType(basic, tv.Value)
+	switch {
+	case ok && n.Kind == token.FLOAT && (kt == "Double" || kt == "Float"):
+		if kt == "Float" {
+			lexical += "f"
+		}
+		t.write(lexical)
+		return
+	case ok && n.Kind == token.INT && kotlinIntKinds[kt] != types.Invalid:
+		t.write(lexical + intSuffix(kt))
+		return
+	}
+	if literal, ok := t.constLiteral(tv.Value, tv.Type); ok {
+		t.write(literal)
+		return
+	}
+	t.write(n.Value)
+}
+
+// kotlinNumberLit normaliza a grafia de um literal numérico do Go. Hexadecimais,
+// binários e separadores _ são mantidos; devolve false para octais e floats
+// hexadecimais, que não existem no Kotlin.
+func kotlinNumberLit(lit string, kind token.Token) (string, bool) {
+	lower := strings.ToLower(lit)
+	if kind == token.INT {
+		switch {
+		case strings.HasPrefix(lower, "0x"), strings.HasPrefix(lower, "0b"):
+			// Go aceita 0x_FF; o Kotlin só aceita _ entre dígitos
+			return lit[:2] + strings.TrimLeft(lit[2:], "_"), true
+		case strings.HasPrefix(lower, "0o"), len(lit) > 1 && lit[0] == '0':
+			return "", false
+		}
+		return lit, true
+	}
+	if strings.HasPrefix(lower, "0x") {
+		return "", false
+	}
+	mantissa, exponent := lit, ""
+	if i := strings.IndexAny(lit, "eE"); i >= 0 {
+		mantissa, exponent = lit[:i], lit[i:]
+	}
+	// Kotlin exige dígitos dos dois lados do ponto (1. e .5 viram 1.0 e 0.5)
+	if strings.HasPrefix(mantissa, ".") {
+		mantissa = "0" + mantissa
+	}
+	if strings.HasSuffix(mantissa, ".") {
+		mantissa += "0"
+	}
+	if whole, frac, found := strings.Cut(mantissa, "."); found || exponent != "" {
+		whole = strings.TrimLeft(whole, "0_")
+		if whole == "" {
+			whole = "0"
+		}
+		if found {
+			mantissa = whole + "." + frac
+		} else {
+			mantissa = whole
+		}
+	}
+	return mantissa + exponent, true
+}
+
+// intSuffix devolve o sufixo de literal do tipo inteiro Kotlin
+func intSuffix(kt string) string {
+	switch kt {
+	case "Long":
+		return "L"
+	case "UByte", "UShort", "UInt":
+		return "u"
+	case "ULong":
+		return "uL"
+	}
+	return ""
+}
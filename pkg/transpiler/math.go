@@ -0,0 +1,233 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+)
+
+// mathFuncs são as funções do math com equivalente de mesmo uso no kotlin.math
+var mathFuncs = map[string]string{
+	"Sqrt":        "sqrt",
+	"Cbrt":        "cbrt",
+	"Abs":         "abs",
+	"Floor":       "floor",
+	"Ceil":        "ceil",
+	"Trunc":       "truncate",
+	"RoundToEven": "round",
+	"Max":         "max",
+	"Min":         "min",
+	"Exp":         "exp",
+	"Expm1":       "expm1",
+	"Log":         "ln",
+	"Log1p":       "ln1p",
+	"Log2":        "log2",
+	"Log10":       "log10",
+	"Sin":         "sin",
+	"Cos":         "cos",
+	"Tan":         "tan",
+	"Asin":        "asin",
+	"Acos":        "acos",
+	"Atan":        "atan",
+	"Atan2":       "atan2",
+	"Sinh":        "sinh",
+	"Cosh":        "cosh",
+	"Tanh":        "tanh",
+	"Asinh":       "asinh",
+	"Acosh":       "acosh",
+	"Atanh":       "atanh",
+	"Hypot":       "hypot",
+}
+
+// mathTemplates traduz as demais funções do math (mesma notação de stringsFuncs)
+var mathTemplates = map[string]string{
+	"Pow":             "%0.pow(%1)",
+	"Copysign":        "%0.withSign(%1)",
+	"Mod":             "%0.rem(%1)",
+	"IsNaN":           "%0.isNaN()",
+	"NaN":             "Double.NaN",
+	"Signbit":         "(%0.toRawBits() < 0)",
+	"Float64bits":     "%0.toRawBits().toULong()",
+	"Float64frombits": "Double.fromBits(%0.toLong())",
+	"Round":           "goRound(%0)",
+}
+
+// mathConst é a constante Kotlin de uma constante do math, usada quando o
+// tipo do contexto é kt; nos demais casos o valor é escrito como literal
+type mathConst struct {
+	kt   string
+	expr string
+}
+
+var mathConsts = map[string]mathConst{
+	"Pi":                     {"Double", "kotlin.math.PI"},
+	"E":                      {"Double", "kotlin.math.E"},
+	"MaxFloat64":             {"Double", "Double.MAX_VALUE"},
+	"SmallestNonzeroFloat64": {"Double", "Double.MIN_VALUE"},
+	"MaxFloat32":             {"Float", "Float.MAX_VALUE"},
+	"SmallestNonzeroFloat32": {"Float", "Float.MIN_VALUE"},
+	"MaxInt":                 {"Int", "Int.MAX_VALUE"},
+	"MinInt":                 {"Int", "Int.MIN_VALUE"},
+	"MaxInt8":                {"Byte", "Byte.MAX_VALUE"},
+	"MinInt8":                {"Byte", "Byte.MIN_VALUE"},
+	"MaxInt16":               {"Short", "Short.MAX_VALUE"},
+	"MinInt16":               {"Short", "Short.MIN_VALUE"},
+	"MaxInt32":               {"Int", "Int.MAX_VALUE"},
+	"MinInt32":               {"Int", "Int.MIN_VALUE"},
+	"MaxInt64":               {"Long", "Long.MAX_VALUE"},
+	"MinInt64":               {"Long", "Long.MIN_VALUE"},
+	"MaxUint":                {"UInt", "UInt.MAX_VALUE"},
+	"MaxUint8":               {"UByte", "UByte.MAX_VALUE"},
+	"MaxUint16":              {"UShort", "UShort.MAX_VALUE"},
+	"MaxUint32":              {"UInt", "UInt.MAX_VALUE"},
+	"MaxUint64":              {"ULong", "ULong.MAX_VALUE"},
+}
+
+// randFuncs traduz as funções do math/rand para kotlin.random.Random; %r é o
+// gerador (Random para as funções do pacote ou um *rand.Rand)
+var randFuncs = map[string]string{
+	"Int":     "%r.nextInt(0, Int.MAX_VALUE)",
+	"Intn":    "%r.nextInt(%0)",
+	"Int31":   "%r.nextInt(0, Int.MAX_VALUE)",
+	"Int31n":  "%r.nextInt(%0)",
+	"Int63":   "%r.nextLong(0, Long.MAX_VALUE)",
+	"Int63n":  "%r.nextLong(%0)",
+	"Float64": "%r.nextDouble()",
+	"Float32": "%r.nextFloat()",
+	"Perm":    "(0 until %0).shuffled(%r).toMutableList()",
+	"Shuffle": "(%0 - 1 downTo 1).forEach { (%1)(it, %r.nextInt(it + 1)) }",
+}
+
+// transpileMath traduz as chamadas aos pacotes math e math/rand e aos métodos de *rand.Rand
+func (t *Transpiler) transpileMath(call *ast.CallExpr) bool {
+	if name := t.pkgMember(call.Fun, "math"); name != "" {
+		switch {
+		case mathFuncs[name] != "":
+			t.useImport("kotlin.math." + mathFuncs[name])
+			t.write(mathFuncs[name])
+			t.writeCallArgs(call)
+			return true
+		case name == "Inf" || name == "IsInf":
+			return t.transpileInf(call, name)
+		case mathTemplates[name] != "":
+			switch name {
+			case "Pow":
+				t.useImport("kotlin.math.pow")
+			case "Copysign":
+				t.useImport("kotlin.math.withSign")
+			case "Round":
+				// Go arredonda metades para longe do zero; o round do Kotlin, para o par
+				t.useRuntime("goRound")
+			}
+			t.writeCallTemplate(mathTemplates[name], nil, call.Args)
+			return true
+		}
+		t.diagnose(call.Pos(), "math."+name+" sem tradução para Kotlin; chamada mantida")
+		return false
+	}
+	if name := t.pkgMember(call.Fun, "math/rand"); name != "" {
+		switch {
+		case name == "Seed":
+			// O Random padrão do Kotlin já começa com uma semente aleatória
+			t.write("// rand.Seed removido: o Random do Kotlin não precisa de semente")
+			return true
+		case name == "NewSource" && len(call.Args) == 1:
+			t.useImport("kotlin.random.Random")
+			t.writeCallTemplate("Random(%0)", nil, call.Args)
+			return true
+		case name == "New" && len(call.Args) == 1:
+			t.Transpile(call.Args[0])
+			return true
+		case randFuncs[name] != "":
+			t.useImport("kotlin.random.Random")
+			t.writeCallTemplate(strings.ReplaceAll(randFuncs[name], "%r", "Random"), nil, call.Args)
+			return true
+		}
+		t.diagnose(call.Pos(), "rand."+name+" sem tradução para Kotlin; chamada mantida")
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || randFuncs[sel.Sel.Name] == "" {
+		return false
+	}
+	recv := t.typeOf(sel.X)
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	if kt, ok := t.stdType(recv); !ok || kt != "Random" {
+		return false
+	}
+	t.writeCallTemplate(randFuncs[sel.Sel.Name], sel.X, call.Args)
+	return true
+}
+
+// transpileInf traduz math.Inf(sign) e math.IsInf(x, sign); com o sinal
+// constante, escolhe diretamente entre POSITIVE_INFINITY e NEGATIVE_INFINITY
+func (t *Transpiler) transpileInf(call *ast.CallExpr, name string) bool {
+	if len(call.Args) == 0 {
+		return false
+	}
+	signArg := call.Args[len(call.Args)-1]
+	val, constSign := t.constValue(signArg)
+	sign := 0
+	if constSign {
+		n, _ := constant.Int64Val(constant.ToInt(val))
+		sign = int(n)
+	}
+	if name == "IsInf" {
+		if len(call.Args) != 2 {
+			return false
+		}
+		switch {
+		case !constSign:
+			t.writeCallTemplate("(%0.isInfinite() && (%1 == 0 || %0 > 0 == %1 > 0))", nil, call.Args)
+		case sign > 0:
+			t.writeCallTemplate("(%0 == Double.POSITIVE_INFINITY)", nil, call.Args)
+		case sign < 0:
+			t.writeCallTemplate("(%0 == Double.NEGATIVE_INFINITY)", nil, call.Args)
+		default:
+			t.writeCallTemplate("%0.isInfinite()", nil, call.Args)
+		}
+		return true
+	}
+	switch {
+	case !constSign:
+		t.writeCallTemplate("(if (%0 >= 0) Double.POSITIVE_INFINITY else Double.NEGATIVE_INFINITY)", nil, call.Args)
+	case sign >= 0:
+		t.write("Double.POSITIVE_INFINITY")
+	default:
+		t.write("Double.NEGATIVE_INFINITY")
+	}
+	return true
+}
+
+// transpileMathConst traduz as constantes do math (math.Pi, math.MaxInt64...)
+// para as constantes do Kotlin quando o tipo do contexto coincide
+func (t *Transpiler) transpileMathConst(sel *ast.SelectorExpr) bool {
+	name := t.pkgMember(sel, "math")
+	mc, ok := mathConsts[name]
+	if !ok {
+		return false
+	}
+	if t.info != nil {
+		tv, ok := t.info.Types[sel]
+		if !ok || tv.Value == nil {
+			return false
+		}
+		if basic, ok := tv.Type.Underlying().(*types.Basic); ok && kotlinBasicType(basic, tv.Value) != mc.kt {
+			literal, ok := t.constLiteral(tv.Value, tv.Type)
+			if ok {
+				t.write(literal)
+			}
+			return ok
+		}
+	}
+	expr := mc.expr
+	if strings.HasPrefix(expr, "kotlin.math.") {
+		t.useImport(expr)
+		expr = strings.TrimPrefix(expr, "kotlin.math.")
+	}
+	t.write(expr)
+	return true
+}
@@ -0,0 +1,398 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// tupleFields são os nomes das propriedades de Pair, Triple e das TupleN geradas
+var tupleFields = []string{"first", "second", "third", "fourth", "fifth", "sixth", "seventh", "eighth", "ninth"}
+
+// tupleClass devolve a classe Kotlin que agrupa n valores de retorno: Pair,
+// Triple ou uma data class TupleN gerada no fim do arquivo
+func (t *Transpiler) tupleClass(n int) string {
+	switch n {
+	case 2:
+		return "Pair"
+	case 3:
+		return "Triple"
+	}
+	name := "Tuple" + strconv.Itoa(n)
+	t.useRuntime(name)
+	return name
+}
+
+// tupleField devolve o nome da propriedade com o i-ésimo valor da tupla
+func tupleField(i int) string {
+	if i < len(tupleFields) {
+		return tupleFields[i]
+	}
+	return "component" + strconv.Itoa(i+1) + "()"
+}
+
+// resultsType devolve o tipo de retorno Kotlin de uma lista de resultados do
+// Go: Unit, o próprio tipo ou uma tupla quando há vários valores
+func (t *Transpiler) resultsType(results *ast.FieldList) string {
+	var types []string
+	if results != nil {
+		for _, field := range results.List {
+			ktType := t.resolveType(field.Type)
+			if typ := t.typeOf(field.Type); typ != nil {
+				ktType = nullableType(ktType, typ)
+			}
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for k := 0; k < count; k++ {
+				types = append(types, ktType)
+			}
+		}
+	}
+	return t.joinResults(types)
+}
+
+// goResultsType é a versão de resultsType para assinaturas do go/types
+func (t *Transpiler) goResultsType(results *types.Tuple) string {
+	var types []string
+	for i := 0; i < results.Len(); i++ {
+		typ := results.At(i).Type()
+		types = append(types, nullableType(t.resolveGoType(typ), typ))
+	}
+	return t.joinResults(types)
+}
+
+func (t *Transpiler) joinResults(types []string) string {
+	switch len(types) {
+	case 0:
+		return "Unit"
+	case 1:
+		return types[0]
+	}
+	return t.tupleClass(len(types)) + "<" + strings.Join(types, ", ") + ">"
+}
+
+// transpileMultiAssign traduz atribuições com vários valores: a, b := f()
+// vira uma desestruturação e a, b = b, a é avaliada antes de atribuir.
+// Em a, err := f() com err já declarado, só as variáveis novas recebem var.
+func (t *Transpiler) transpileMultiAssign(n *ast.AssignStmt) bool {
+	if len(n.Lhs) < 2 {
+		return false
+	}
+	define := n.Tok == token.DEFINE
+	if len(n.Rhs) == 1 {
+		return t.transpileDestructuring(n.Lhs, n.Rhs[0], define)
+	}
+	if len(n.Rhs) != len(n.Lhs) {
+		return false
+	}
+	if !define && t.isSwap(n) {
+		t.transpileTarget(n.Lhs[0])
+		t.write(" = ")
+		t.Transpile(n.Rhs[0])
+		t.write(".also { ")
+		t.transpileTarget(n.Lhs[1])
+		t.write(" = ")
+		t.Transpile(n.Rhs[1])
+		t.write(" }")
+		return true
+	}
+	// Todos os valores são calculados antes das atribuições, como no Go
+	values := make([]string, len(n.Rhs))
+	parallel := !define && t.readsTargets(n)
+	lines := 0
+	newline := func() {
+		if lines > 0 {
+			t.write("\n")
+			t.writeIndent()
+		}
+		lines++
+	}
+	lhsList := n.Lhs
+	if parallel {
+		// Os operandos de índices e ponteiros à esquerda também são avaliados antes
+		lhsList = make([]ast.Expr, len(n.Lhs))
+		targets := make(map[string]bool)
+		for i, lhs := range n.Lhs {
+			lhsList[i] = t.hoistTargetOperands(lhs, targets, newline)
+			if root := rootIdent(lhs); root != nil && root.Name != "_" {
+				targets[root.Name] = true
+			}
+		}
+		for i, rhs := range n.Rhs {
+			values[i] = t.newTemp()
+			newline()
+			t.write("val " + values[i] + " = ")
+			t.transpileCopied(rhs)
+		}
+	}
+	for i, lhs := range lhsList {
+		if id, ok := lhs.(*ast.Ident); ok && id.Name == "_" {
+			if !parallel {
+				newline()
+				t.Transpile(n.Rhs[i])
+			}
+			continue
+		}
+		newline()
+		t.writeAssignTarget(lhs, define)
+		if parallel {
+			t.write(values[i])
+		} else {
+			t.transpileAssignedValue(lhs, n.Rhs[i], define)
+		}
+	}
+	return true
+}
+
+// transpileDestructuring traduz a, b := f() para val (a, b) = f(). Quando
+// algum alvo não é uma variável nova (ou precisa de Ref), ou no nível do
+// pacote, onde o Kotlin não aceita desestruturação, usa uma variável
+// auxiliar e atribui cada componente.
+func (t *Transpiler) transpileDestructuring(lhs []ast.Expr, rhs ast.Expr, define bool) bool {
+	simple := define && t.fn != nil
+	for _, target := range lhs {
+		id, ok := target.(*ast.Ident)
+		if !ok || (id.Name != "_" && (!t.isNewVar(id, define) || t.isBoxed(id))) {
+			simple = false
+		}
+	}
+	if simple {
+		var names []string
+		keyword := "val"
+		for _, target := range lhs {
+			id := target.(*ast.Ident)
+			names = append(names, t.ident(id))
+			if id.Name != "_" && t.declKeyword(id) == "var" {
+				keyword = "var"
+			}
+		}
+		t.write(keyword + " (" + strings.Join(names, ", ") + ") = ")
+		t.Transpile(rhs)
+		return true
+	}
+	result := t.newTemp()
+	if t.fn == nil {
+		t.write("private ")
+	}
+	t.write("val " + result + " = ")
+	t.Transpile(rhs)
+	for i, target := range lhs {
+		if id, ok := target.(*ast.Ident); ok && id.Name == "_" {
+			continue
+		}
+		t.write("\n")
+		t.writeIndent()
+		t.writeAssignTarget(target, define)
+		value := result + "." + tupleField(i)
+		if id, ok := target.(*ast.Ident); ok && t.isNewVar(id, define) && t.isBoxed(id) {
+			value = "Ref(" + value + ")"
+		}
+		t.write(value)
+	}
+	return true
+}
+
+// writeAssignTarget escreve "var x = " para variáveis novas e "x = " para as demais
+func (t *Transpiler) writeAssignTarget(lhs ast.Expr, define bool) {
+	if id, ok := lhs.(*ast.Ident); ok && t.isNewVar(id, define) {
+		t.write(t.declKeyword(id) + " ")
+	}
+	t.transpileTarget(lhs)
+	t.write(" = ")
+}
+
+// isSwap informa se a atribuição é a troca a, b = b, a
+func (t *Transpiler) isSwap(n *ast.AssignStmt) bool {
+	if len(n.Lhs) != 2 {
+		return false
+	}
+	return sameExpr(n.Lhs[0], n.Rhs[1]) && sameExpr(n.Lhs[1], n.Rhs[0])
+}
+
+// sameExpr compara duas expressões simples (identificadores, seletores e índices)
+func sameExpr(a, b ast.Expr) bool {
+	switch x := ast.Unparen(a).(type) {
+	case *ast.Ident:
+		y, ok := ast.Unparen(b).(*ast.Ident)
+		return ok && x.Name == y.Name && x.Name != "_"
+	case *ast.SelectorExpr:
+		y, ok := ast.Unparen(b).(*ast.SelectorExpr)
+		return ok && x.Sel.Name == y.Sel.Name && sameExpr(x.X, y.X)
+	case *ast.IndexExpr:
+		y, ok := ast.Unparen(b).(*ast.IndexExpr)
+		return ok && sameExpr(x.X, y.X) && sameExpr(x.Index, y.Index)
+	case *ast.BasicLit:
+		y, ok := ast.Unparen(b).(*ast.BasicLit)
+		return ok && x.Kind == y.Kind && x.Value == y.Value
+	}
+	return false
+}
+
+// readsTargets informa se algum valor à direita, ou algum índice ou ponteiro
+// à esquerda (i, xs[i] = 1, 9), lê uma variável atribuída antes dele, caso em
+// que a atribuição sequencial mudaria o resultado
+func (t *Transpiler) readsTargets(n *ast.AssignStmt) bool {
+	targets := make(map[string]bool)
+	for i := 1; i < len(n.Rhs); i++ {
+		if root := rootIdent(n.Lhs[i-1]); root != nil && root.Name != "_" {
+			targets[root.Name] = true
+		}
+		if readsAny(n.Rhs[i], targets) {
+			return true
+		}
+		if _, plain := n.Lhs[i].(*ast.Ident); !plain && readsAny(n.Lhs[i], targets) {
+			return true
+		}
+	}
+	return false
+}
+
+// readsAny informa se a expressão lê alguma das variáveis
+func readsAny(expr ast.Expr, vars map[string]bool) bool {
+	found := false
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if id, ok := node.(*ast.Ident); ok && vars[id.Name] {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// hoistTargetOperands guarda em variáveis auxiliares os operandos de um alvo
+// que leem alvos anteriores: o índice de xs[i], o ponteiro de *p e de p.f e a
+// slice ou o map indexado. Arrays e structs são valores e não são guardados,
+// pois o Go atribui à própria variável. Devolve o alvo com os auxiliares.
+func (t *Transpiler) hoistTargetOperands(lhs ast.Expr, targets map[string]bool, newline func()) ast.Expr {
+	if t.info == nil || len(targets) == 0 {
+		return lhs
+	}
+	hoist := func(expr ast.Expr) ast.Expr {
+		if !readsAny(expr, targets) {
+			return expr
+		}
+		name := t.newTemp()
+		newline()
+		t.write("val " + name + " = ")
+		t.Transpile(expr)
+		temp := ast.NewIdent(name)
+		temp.NamePos = expr.Pos()
+		t.info.Types[temp] = t.info.Types[expr]
+		return temp
+	}
+	isRef := func(expr ast.Expr) bool {
+		typ := t.typeOf(expr)
+		if typ == nil {
+			return false
+		}
+		switch typ.Underlying().(type) {
+		case *types.Slice, *types.Map, *types.Pointer:
+			return true
+		}
+		return false
+	}
+	var rewritten ast.Expr
+	switch e := ast.Unparen(lhs).(type) {
+	case *ast.IndexExpr:
+		x := e.X
+		if isRef(e.X) {
+			x = hoist(e.X)
+		}
+		rewritten = &ast.IndexExpr{X: x, Lbrack: e.Lbrack, Index: hoist(e.Index), Rbrack: e.Rbrack}
+	case *ast.StarExpr:
+		rewritten = &ast.StarExpr{Star: e.Star, X: hoist(e.X)}
+	case *ast.SelectorExpr:
+		if !isRef(e.X) {
+			return lhs
+		}
+		rewritten = &ast.SelectorExpr{X: hoist(e.X), Sel: e.Sel}
+		if sel, ok := t.info.Selections[e]; ok {
+			t.info.Selections[rewritten.(*ast.SelectorExpr)] = sel
+		}
+	default:
+		return lhs
+	}
+	t.info.Types[rewritten] = t.info.Types[lhs]
+	return rewritten
+}
+
+// rootIdent devolve o identificador na raiz de x, x.f, x[i] ou *x
+func rootIdent(expr ast.Expr) *ast.Ident {
+	for {
+		switch e := ast.Unparen(expr).(type) {
+		case *ast.Ident:
+			return e
+		case *ast.SelectorExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		default:
+			return nil
+		}
+	}
+}
+
+// transpileReturnValues escreve os valores de return, agrupando vários em uma tupla
+func (t *Transpiler) transpileReturnValues(results []ast.Expr) {
+	if len(results) == 1 {
+		t.transpileReturned(results[0])
+		return
+	}
+	t.write(t.tupleClass(len(results)) + "(")
+	for i, res := range results {
+		if i > 0 {
+			t.write(", ")
+		}
+		t.transpileReturned(res)
+	}
+	t.write(")")
+}
+
+// transpileReturned escreve um valor retornado. Variáveis locais deixam de
+// existir no return e não precisam de cópia; campos e elementos sim.
+func (t *Transpiler) transpileReturned(expr ast.Expr) {
+	if id, ok := ast.Unparen(expr).(*ast.Ident); ok {
+		if obj := t.objectOf(id); obj != nil && !t.isPackageLevel(obj) {
+			t.Transpile(expr)
+			return
+		}
+	}
+	t.transpileCopied(expr)
+}
+
+// transpileSpreadCall trata f(g()) com g de vários retornos: a tupla de g vai
+// para uma variável auxiliar e cada componente vira um argumento de f.
+func (t *Transpiler) transpileSpreadCall(n *ast.CallExpr) bool {
+	if t.info == nil {
+		return false
+	}
+	for i, arg := range n.Args {
+		tuple, ok := t.typeOf(arg).(*types.Tuple)
+		if !ok || tuple.Len() < 2 {
+			continue
+		}
+		name := t.newTemp()
+		args := append([]ast.Expr{}, n.Args[:i]...)
+		for j := 0; j < tuple.Len(); j++ {
+			field := ast.NewIdent(name + "." + tupleField(j))
+			field.NamePos = arg.Pos()
+			t.info.Types[field] = types.TypeAndValue{Type: tuple.At(j).Type()}
+			args = append(args, field)
+		}
+		args = append(args, n.Args[i+1:]...)
+		spread := &ast.CallExpr{Fun: n.Fun, Lparen: n.Lparen, Args: args, Rparen: n.Rparen}
+		t.info.Types[spread] = t.info.Types[n]
+		t.Transpile(arg)
+		t.write(".let { " + name + " -> ")
+		t.Transpile(spread)
+		t.write(" }")
+		return true
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// analyzeMutability marca as variáveis e os campos de struct que são
+// reatribuídos (=, op=, ++, range com =, &x) depois da declaração. Os demais
+// podem ser declarados como val no Kotlin.
+func (t *Transpiler) analyzeMutability(file *ast.File) {
+	if t.info == nil {
+		return
+	}
+	mark := func(expr ast.Expr) {
+		switch e := ast.Unparen(expr).(type) {
+		case *ast.Ident:
+			if obj := t.info.Uses[e]; obj != nil {
+				t.reassigned[obj] = true
+			}
+		case *ast.SelectorExpr:
+			if s, ok := t.info.Selections[e]; ok && s.Kind() == types.FieldVal {
+				t.reassigned[s.Obj()] = true
+			}
+		case *ast.StarExpr:
+			// *p = v copia todos os campos da struct apontada
+			if elem := t.pointerElem(e.X); elem != nil && isStructType(elem) {
+				st := elem.Underlying().(*types.Struct)
+				for i := 0; i < st.NumFields(); i++ {
+					t.reassigned[st.Field(i)] = true
+				}
+			}
+		}
+	}
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				// Em a, err := f(), um err já existente aparece em Uses
+				mark(lhs)
+			}
+		case *ast.IncDecStmt:
+			mark(n.X)
+		case *ast.RangeStmt:
+			if n.Tok == token.ASSIGN {
+				if n.Key != nil {
+					mark(n.Key)
+				}
+				if n.Value != nil {
+					mark(n.Value)
+				}
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND {
+				mark(n.X)
+			}
+		}
+		return true
+	})
+}
+
+// declKeyword devolve val para variáveis nunca reatribuídas e var para as demais
+func (t *Transpiler) declKeyword(id *ast.Ident) string {
+	if t.info == nil {
+		return "var"
+	}
+	obj := t.info.Defs[id]
+	if obj == nil || t.reassigned[obj] || t.boxed[obj] {
+		return "var"
+	}
+	return "val"
+}
+
+// fieldKeyword devolve val para campos nunca escritos depois da construção,
+// quando a opção ValFields está ativa
+func (t *Transpiler) fieldKeyword(f *types.Var) string {
+	if t.options.ValFields && f != nil && !t.reassigned[f] {
+		return "val"
+	}
+	return "var"
+}
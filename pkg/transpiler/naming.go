@@ -0,0 +1,141 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// kotlinKeywords são as palavras reservadas (hard keywords) do Kotlin. Um
+// identificador Go com um desses nomes é escrito entre crases (ex: `in`).
+var kotlinKeywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true,
+	"else": true, "for": true, "fun": true, "if": true, "in": true,
+	"interface": true, "is": true, "null": true, "object": true, "package": true,
+	"return": true, "super": true, "this": true, "throw": true, "try": true,
+	"typealias": true, "typeof": true, "val": true, "var": true, "when": true,
+	"while": true,
+}
+
+// kotlinReserved são as funções e tipos do Kotlin usados pelo código gerado.
+// Declarações do usuário com esses nomes são renomeadas para não escondê-los.
+var kotlinReserved = map[string]bool{
+	"println": true, "print": true, "delay": true, "launch": true, "runBlocking": true,
+	"listOf": true, "mutableListOf": true, "mutableMapOf": true, "arrayOf": true,
+	"minOf": true, "maxOf": true, "repeat": true, "run": true, "also": true,
+	"Pair": true, "Triple": true, "Ref": true, "Channel": true, "Any": true, "Unit": true,
+	"String": true, "Int": true, "Long": true, "Short": true, "Byte": true, "Char": true,
+	"Double": true, "Float": true, "Boolean": true, "UInt": true, "ULong": true,
+	"UShort": true, "UByte": true, "List": true, "MutableList": true, "Map": true,
+	"MutableMap": true, "Array": true, "Character": true, "System": true,
+}
+
+// kotlinImported são os nomes trazidos por imports do código gerado (useImport)
+var kotlinImported = []string{
+	"pow", "withSign", "Random", "exitProcess", "Instant", "ZoneId",
+	"DateTimeFormatter", "Duration", "nanoseconds", "TimeSource",
+}
+
+// init completa kotlinReserved com os nomes importados, as funções e
+// constantes do kotlin.math e as declarações do runtime
+func init() {
+	for _, name := range kotlinImported {
+		kotlinReserved[name] = true
+	}
+	for _, name := range mathFuncs {
+		kotlinReserved[name] = true
+	}
+	for _, c := range mathConsts {
+		if name, ok := strings.CutPrefix(c.expr, "kotlin.math."); ok {
+			kotlinReserved[name] = true
+		}
+	}
+	for _, name := range runtimeNames() {
+		kotlinReserved[name] = true
+	}
+}
+
+// analyzeNames escolhe novos nomes para as declarações do pacote que colidem
+// com nomes do Kotlin (ex: uma função println vira println_). Todas as
+// declarações com o mesmo nome recebem o mesmo substituto, livre no arquivo.
+// Os nomes usados ficam em usedNames para as variáveis auxiliares (newTemp).
+func (t *Transpiler) analyzeNames(file *ast.File) {
+	if t.info == nil {
+		return
+	}
+	used := make(map[string]bool)
+	ast.Inspect(file, func(node ast.Node) bool {
+		if id, ok := node.(*ast.Ident); ok {
+			used[id.Name] = true
+		}
+		return true
+	})
+	t.usedNames = used
+	chosen := make(map[string]string)
+	for id, obj := range t.info.Defs {
+		if obj == nil || obj.Pkg() != t.pkg || !kotlinReserved[id.Name] || isMember(obj) {
+			continue
+		}
+		name, ok := chosen[id.Name]
+		if !ok {
+			name = id.Name + "_"
+			for used[name] {
+				name += "_"
+			}
+			used[name] = true
+			chosen[id.Name] = name
+		}
+		t.renames[obj] = name
+	}
+}
+
+// freshName devolve base ou, se um identificador do arquivo Go já usa esse
+// nome, base seguido de _: nomes do código gerado que não podem ser escondidos
+// por variáveis do usuário (defers, panicking, o rótulo body@)
+func (t *Transpiler) freshName(base string) string {
+	name := base
+	for t.usedNames[name] {
+		name += "_"
+	}
+	return name
+}
+
+// isMember informa se o objeto é um campo ou método, acessados sempre com um
+// receptor e que por isso não escondem nomes do Kotlin
+func isMember(obj types.Object) bool {
+	switch o := obj.(type) {
+	case *types.Var:
+		return o.IsField()
+	case *types.Func:
+		return o.Type().(*types.Signature).Recv() != nil
+	}
+	return false
+}
+
+// ident devolve o nome Kotlin de um identificador: renomeado se colide com o
+// Kotlin e entre crases se é uma palavra reservada
+func (t *Transpiler) ident(id *ast.Ident) string {
+	if obj := t.objectOf(id); obj != nil {
+		return t.objName(obj)
+	}
+	return kotlinName(id.Name)
+}
+
+// objName é a versão de ident para objetos do go/types
+func (t *Transpiler) objName(obj types.Object) string {
+	if name, ok := t.renames[obj]; ok {
+		return name
+	}
+	if obj.Parent() == types.Universe {
+		return obj.Name()
+	}
+	return kotlinName(obj.Name())
+}
+
+// kotlinName escreve entre crases os nomes que são palavras reservadas do Kotlin
+func kotlinName(name string) string {
+	if kotlinKeywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
@@ -0,0 +1,416 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// bitwiseOps mapeia os operadores bit a bit do Go para as funções infix do Kotlin
+var bitwiseOps = map[token.Token]string{
+	token.AND:     "and",
+	token.OR:      "or",
+	token.XOR:     "xor",
+	token.AND_NOT: "and",
+	token.SHL:     "shl",
+	token.SHR:     "shr",
+}
+
+// compoundOps mapeia as atribuições compostas para o operador binário base
+var compoundOps = map[token.Token]token.Token{
+	token.ADD_ASSIGN:     token.ADD,
+	token.SUB_ASSIGN:     token.SUB,
+	token.MUL_ASSIGN:     token.MUL,
+	token.QUO_ASSIGN:     token.QUO,
+	token.REM_ASSIGN:     token.REM,
+	token.AND_ASSIGN:     token.AND,
+	token.OR_ASSIGN:      token.OR,
+	token.XOR_ASSIGN:     token.XOR,
+	token.SHL_ASSIGN:     token.SHL,
+	token.SHR_ASSIGN:     token.SHR,
+	token.AND_NOT_ASSIGN: token.AND_NOT,
+}
+
+// kotlinIntKinds associa os tipos inteiros do Kotlin a um tipo básico do Go,
+// usado para formatar constantes no tipo em que a operação é calculada
+var kotlinIntKinds = map[string]types.BasicKind{
+	"Byte":   types.Int8,
+	"Short":  types.Int16,
+	"Int":    types.Int32,
+	"Long":   types.Int64,
+	"UByte":  types.Uint8,
+	"UShort": types.Uint16,
+	"UInt":   types.Uint32,
+	"ULong":  types.Uint64,
+}
+
+// intOp descreve como uma operação inteira é calculada no Kotlin
+type intOp struct {
+	kt     string       // tipo Kotlin do resultado
+	calc   string       // tipo Kotlin em que a operação é calculada
+	narrow bool         // o resultado precisa voltar ao tipo original
+	class  *types.Named // value class do resultado, se houver
+}
+
+// planIntOp decide se a operação precisa de tradução: operadores bit a bit
+// viram funções infix e, como Byte e Short não têm and/or/shl no Kotlin (e a
+// aritmética deles resulta em Int), o cálculo é feito em Int e convertido de volta
+func (t *Transpiler) planIntOp(op token.Token, typ types.Type) (intOp, bool) {
+	if typ == nil {
+		return intOp{}, false
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsInteger == 0 {
+		return intOp{}, false
+	}
+	plan := intOp{kt: kotlinBasicType(basic, nil), class: t.valueClassOf(typ)}
+	if _, isInt := kotlinIntKinds[plan.kt]; !isInt {
+		return intOp{}, false
+	}
+	plan.calc = plan.kt
+	_, bitwise := bitwiseOps[op]
+	if !bitwise {
+		// Value classes já declaram os operadores aritméticos
+		if plan.class != nil || !isSmallInt(plan.kt) {
+			return intOp{}, false
+		}
+		switch op {
+		case token.ADD, token.SUB, token.MUL, token.QUO, token.REM:
+			plan.narrow = true
+			return plan, true
+		}
+		return intOp{}, false
+	}
+	shift := op == token.SHL || op == token.SHR
+	switch plan.kt {
+	case "Byte", "Short":
+		plan.calc = "Int"
+	case "UByte", "UShort":
+		if shift {
+			plan.calc = "UInt"
+		}
+	}
+	plan.narrow = plan.calc != plan.kt
+	return plan, true
+}
+
+// isSmallInt informa se o tipo Kotlin é um inteiro cuja aritmética resulta em Int/UInt
+func isSmallInt(kt string) bool {
+	return narrowTo(kt, "") != ""
+}
+
+// transpileIntOp escreve x op y seguindo o plano: (a and b), (a shl 2),
+// (a and b.inv()) para &^ e (a + b).toByte() para tipos pequenos
+func (t *Transpiler) transpileIntOp(plan intOp, x ast.Expr, op token.Token, y ast.Expr) {
+	if plan.class != nil {
+		t.write(t.objName(plan.class.Obj()) + "(")
+	}
+	paren := plan.class == nil || plan.narrow
+	if paren {
+		t.write("(")
+	}
+	t.writeIntOperand(x, plan.calc, false)
+	switch op {
+	case token.SHL, token.SHR:
+		name := bitwiseOps[op]
+		if op == token.SHR && t.isUnsigned(x) && !strings.HasPrefix(plan.calc, "U") {
+			// Inteiro sem sinal do Go guardado em um tipo com sinal do Kotlin
+			name = "ushr"
+		}
+		t.write(" " + name + " ")
+		t.writeShiftCount(y)
+	case token.AND_NOT:
+		t.write(" and ")
+		if tv, ok := t.info.Types[y]; ok && tv.Value != nil {
+			// Máscara constante: a inversão é calculada na tradução
+			kind := kotlinIntKinds[plan.calc]
+			prec := uint(0)
+			if strings.HasPrefix(plan.calc, "U") {
+				prec = uint(8 * types.SizesFor("gc", "amd64").Sizeof(types.Typ[kind]))
+			}
+			if literal, ok := t.constLiteral(constant.UnaryOp(token.XOR, tv.Value, prec), types.Typ[kind]); ok {
+				t.write(literal)
+				break
+			}
+		}
+		t.writeIntOperand(y, plan.calc, true)
+		t.write(".inv()")
+	default:
+		if name, ok := bitwiseOps[op]; ok {
+			t.write(" " + name + " ")
+		} else {
+			t.write(" " + op.String() + " ")
+		}
+		t.writeIntOperand(y, plan.calc, false)
+	}
+	if paren {
+		t.write(")")
+	}
+	if plan.narrow {
+		t.write(".to" + plan.kt + "()")
+	}
+	if plan.class != nil {
+		t.write(")")
+	}
+}
+
+// writeIntOperand escreve um operando no tipo Kotlin do cálculo, desembrulhando
+// value classes e convertendo quando o tipo for diferente. Com receiver, o
+// operando é envolvido em parênteses para receber uma chamada (ex: (a + b).inv()).
+func (t *Transpiler) writeIntOperand(expr ast.Expr, calc string, receiver bool) {
+	if tv, ok := t.info.Types[expr]; ok && tv.Value != nil {
+		if literal, ok := t.constLiteral(tv.Value, types.Typ[kotlinIntKinds[calc]]); ok {
+			if receiver && strings.HasPrefix(literal, "-") {
+				literal = "(" + literal + ")"
+			}
+			t.write(literal)
+			return
+		}
+	}
+	typ := t.typeOf(expr)
+	class := t.hasValue(typ)
+	src := calc
+	if typ != nil {
+		if basic, ok := typ.Underlying().(*types.Basic); ok {
+			src = kotlinBasicType(basic, nil)
+		}
+	}
+	if !class && src == calc && !receiver {
+		t.Transpile(expr)
+		return
+	}
+	t.transpileOperand(expr)
+	if class {
+		t.write(".value")
+	}
+	if src == "Char" {
+		t.write(".code")
+		src = "Int"
+	}
+	if src != calc {
+		t.write(".to" + calc + "()")
+	}
+}
+
+// writeShiftCount escreve a quantidade de um deslocamento, que no Kotlin é sempre Int
+func (t *Transpiler) writeShiftCount(expr ast.Expr) {
+	if tv, ok := t.info.Types[expr]; ok && tv.Value != nil {
+		if n, ok := constant.Int64Val(constant.ToInt(tv.Value)); ok {
+			t.write(strconv.FormatInt(n, 10))
+			return
+		}
+	}
+	t.writeIntOperand(expr, "Int", false)
+}
+
+// isUnsigned informa se a expressão tem um tipo inteiro sem sinal no Go
+func (t *Transpiler) isUnsigned(expr ast.Expr) bool {
+	typ := t.typeOf(expr)
+	if typ == nil {
+		return false
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsUnsigned != 0
+}
+
+// transpileIntUnary traduz ^x para x.inv() e a negação de inteiros sem sinal,
+// que o Kotlin não define, para uma subtração a partir de zero
+func (t *Transpiler) transpileIntUnary(n *ast.UnaryExpr) bool {
+	if t.info == nil || (n.Op != token.XOR && n.Op != token.SUB) {
+		return false
+	}
+	tv, ok := t.info.Types[n]
+	if !ok {
+		return false
+	}
+	plan, ok := t.planIntOp(token.XOR, tv.Type)
+	if !ok {
+		return false
+	}
+	if tv.Value != nil {
+		return n.Op == token.XOR && t.transpileConstExpr(n)
+	}
+	if n.Op == token.SUB {
+		plan.narrow = isSmallInt(plan.kt)
+		switch {
+		case plan.class != nil:
+			return false
+		case strings.HasPrefix(plan.kt, "U"):
+			zero := "0u"
+			if plan.kt == "ULong" {
+				zero = "0uL"
+			}
+			t.write("(" + zero + " - ")
+			t.Transpile(n.X)
+			t.write(")")
+		case plan.narrow:
+			t.write("(-")
+			t.Transpile(n.X)
+			t.write(")")
+		default:
+			return false
+		}
+		if plan.narrow {
+			t.write(".to" + plan.kt + "()")
+		}
+		return true
+	}
+	if plan.class != nil {
+		t.write(t.objName(plan.class.Obj()) + "(")
+	}
+	t.writeIntOperand(n.X, plan.calc, true)
+	t.write(".inv()")
+	if plan.calc != plan.kt {
+		t.write(".to" + plan.kt + "()")
+	}
+	if plan.class != nil {
+		t.write(")")
+	}
+	return true
+}
+
+// transpileConstExpr escreve uma expressão constante já calculada pelo
+// go/types, no tipo do resultado: 10 / 4 em um float64 vira 2.5, -3 vira -3.0
+// e literais de um tipo pequeno não são combinados como Int/UInt
+func (t *Transpiler) transpileConstExpr(expr ast.Expr) bool {
+	if t.info == nil {
+		return false
+	}
+	tv, ok := t.info.Types[expr]
+	if !ok || tv.Value == nil {
+		return false
+	}
+	if t.enumOf(tv.Type) != nil {
+		return t.transpileEnumConst(expr)
+	}
+	literal, ok := t.constLiteral(tv.Value, tv.Type)
+	if !ok {
+		return false
+	}
+	if named := t.valueClassOf(tv.Type); named != nil {
+		literal = t.objName(named.Obj()) + "(" + literal + ")"
+	}
+	t.write(literal)
+	return true
+}
+
+// transpileCompoundAssign traduz x op= y. Operações que o Kotlin não tem como
+// atribuição composta (bit a bit, tipos pequenos, elementos de map) são
+// expandidas para x = x op y.
+func (t *Transpiler) transpileCompoundAssign(lhs ast.Expr, op token.Token, rhs ast.Expr) {
+	plan, special := t.planIntOp(op, t.typeOf(lhs))
+	if special || t.isMapIndex(lhs) {
+		t.transpileTarget(lhs)
+		t.write(" = ")
+		if special {
+			t.transpileIntOp(plan, lhs, op, rhs)
+			return
+		}
+		t.Transpile(lhs)
+		t.write(" " + op.String() + " ")
+		t.transpileOperand(rhs)
+		return
+	}
+	t.Transpile(lhs)
+	t.write(" " + op.String() + "= ")
+	t.Transpile(rhs)
+}
+
+// isMapIndex informa se a expressão é a leitura de um elemento de map
+func (t *Transpiler) isMapIndex(expr ast.Expr) bool {
+	idx, ok := ast.Unparen(expr).(*ast.IndexExpr)
+	if !ok {
+		return false
+	}
+	typ := t.typeOf(idx.X)
+	if typ == nil {
+		return false
+	}
+	_, isMap := typ.Underlying().(*types.Map)
+	return isMap
+}
+
+// isRune informa se o tipo é uma runa, que no Kotlin é Char
+func isRune(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && kotlinBasicType(basic, nil) == "Char"
+}
+
+// isIntegerType informa se o tipo (ou seu tipo subjacente) é inteiro, incluindo runas
+func isIntegerType(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsInteger != 0
+}
+
+// isIntType informa se o tipo é traduzido como Int do Kotlin
+func isIntType(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && kotlinBasicType(basic, nil) == "Int"
+}
+
+// transpileRuneOp traduz a aritmética de runas. O Kotlin só define Char ± Int
+// (resultando em Char) e Char - Char (resultando em Int): r + 1 continua
+// r + 1, e as demais operações são calculadas sobre .code e voltam com
+// .toChar(). Com wantInt, o resultado é deixado como Int (ex: int(r - '0')).
+func (t *Transpiler) transpileRuneOp(n *ast.BinaryExpr, wantInt bool) bool {
+	if t.info == nil || !isRune(t.typeOf(n)) || t.valueClassOf(t.typeOf(n)) != nil {
+		return false
+	}
+	constX := t.isConst(n.X)
+	constY := t.isConst(n.Y)
+	switch {
+	case n.Op == token.SUB && wantInt && !constX:
+		// Char - Char já é Int no Kotlin
+		t.Transpile(n.X)
+		t.write(" - ")
+		t.Transpile(n.Y)
+		return true
+	case !wantInt && (n.Op == token.ADD || n.Op == token.SUB) && constY && !constX:
+		t.Transpile(n.X)
+		t.write(" " + n.Op.String() + " ")
+		t.writeIntOperand(n.Y, "Int", false)
+		return true
+	case !wantInt && n.Op == token.ADD && constX && !constY:
+		t.Transpile(n.Y)
+		t.write(" + ")
+		t.writeIntOperand(n.X, "Int", false)
+		return true
+	}
+	t.transpileIntOp(intOp{kt: "Int", calc: "Int"}, n.X, n.Op, n.Y)
+	if !wantInt {
+		t.write(".toChar()")
+	}
+	return true
+}
+
+// transpileRuneUnary traduz -r e ^r, que o Char do Kotlin não define
+func (t *Transpiler) transpileRuneUnary(n *ast.UnaryExpr) bool {
+	if t.info == nil || (n.Op != token.SUB && n.Op != token.XOR) || !isRune(t.typeOf(n)) || t.valueClassOf(t.typeOf(n)) != nil {
+		return false
+	}
+	t.write("(")
+	if n.Op == token.SUB {
+		t.write("-")
+		t.writeIntOperand(n.X, "Int", false)
+	} else {
+		t.writeIntOperand(n.X, "Int", true)
+		t.write(".inv()")
+	}
+	t.write(").toChar()")
+	return true
+}
+
+// isConst informa se a expressão é uma constante conhecida pelo go/types
+func (t *Transpiler) isConst(expr ast.Expr) bool {
+	tv, ok := t.info.Types[expr]
+	return ok && tv.Value != nil
+}
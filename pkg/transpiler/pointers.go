@@ -0,0 +1,385 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// analyzePointers decide quais variáveis precisam de uma caixa mutável (Ref):
+// as que não são structs e têm o endereço tomado (&x). Structs já são
+// referências no Kotlin, então &v apenas compartilha o objeto. Também marca
+// as variáveis de ponteiro que nunca recebem nil, dispensando o uso de !!.
+func (t *Transpiler) analyzePointers(file *ast.File) {
+	if t.info == nil {
+		return
+	}
+	nullable := make(map[types.Object]bool)
+	// Os destinos de errors.As e fmt.Scan* viram atribuições em lambdas, sem caixa
+	lambdaTargets := make(map[ast.Expr]bool)
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			for _, arg := range t.assignedArgs(n) {
+				lambdaTargets[ast.Unparen(arg)] = true
+			}
+		case *ast.UnaryExpr:
+			if n.Op != token.AND || lambdaTargets[n] {
+				return true
+			}
+			id, ok := ast.Unparen(n.X).(*ast.Ident)
+			if !ok {
+				return true
+			}
+			v, ok := t.objectOf(id).(*types.Var)
+			if !ok || v.IsField() {
+				return true
+			}
+			if _, isStruct := v.Type().Underlying().(*types.Struct); !isStruct {
+				t.boxed[v] = true
+				t.useRuntime("Ref")
+			}
+		case *ast.FuncDecl:
+			if n.Recv != nil && len(n.Recv.List) > 0 {
+				for _, name := range n.Recv.List[0].Names {
+					if obj := t.objectOf(name); obj != nil {
+						t.nonNull[obj] = true
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				id, ok := lhs.(*ast.Ident)
+				if !ok || len(n.Lhs) != len(n.Rhs) {
+					continue
+				}
+				obj := t.objectOf(id)
+				if obj == nil {
+					continue
+				}
+				if _, isPtr := obj.Type().Underlying().(*types.Pointer); !isPtr {
+					continue
+				}
+				if t.isNonNullExpr(n.Rhs[i]) && t.info.Defs[id] != nil {
+					t.nonNull[obj] = true
+				} else {
+					nullable[obj] = true
+				}
+			}
+		}
+		return true
+	})
+	for obj := range nullable {
+		delete(t.nonNull, obj)
+	}
+}
+
+// assignedArgs devolve os argumentos de ponteiro que a tradução da chamada
+// escreve como atribuições: o destino de errors.As e os de fmt.Scan*
+func (t *Transpiler) assignedArgs(call *ast.CallExpr) []ast.Expr {
+	if t.pkgMember(call.Fun, "errors") == "As" && len(call.Args) == 2 {
+		return call.Args[1:]
+	}
+	if sf, ok := scanFuncs[t.fmtCall(call)]; ok && len(call.Args) >= sf.first {
+		return call.Args[sf.first:]
+	}
+	if name := t.pkgMember(call.Fun, "encoding/json"); name == "Unmarshal" && len(call.Args) == 2 {
+		return call.Args[1:]
+	}
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Decode" && t.jsonType(sel.X) == "Decoder" {
+		return call.Args
+	}
+	return nil
+}
+
+// isNonNullExpr informa se a expressão de ponteiro nunca é nil (&x, new(T), bufio.NewX, time.NewTimer, json.NewX, receivers)
+func (t *Transpiler) isNonNullExpr(expr ast.Expr) bool {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.UnaryExpr:
+		return e.Op == token.AND
+	case *ast.CompositeLit:
+		return true
+	case *ast.CallExpr:
+		if id, ok := e.Fun.(*ast.Ident); ok && id.Name == "new" {
+			_, isBuiltin := t.objectOf(id).(*types.Builtin)
+			return isBuiltin
+		}
+		// Os construtores do bufio e do json e os temporizadores viram objetos do Kotlin, nunca nulos
+		name := t.pkgMember(e.Fun, "time")
+		if ctor := t.pkgMember(e.Fun, "encoding/json"); ctor == "NewEncoder" || ctor == "NewDecoder" {
+			return true
+		}
+		return t.pkgMember(e.Fun, "bufio") != "" || name == "NewTimer" || name == "NewTicker"
+	case *ast.Ident:
+		if obj := t.objectOf(e); obj != nil {
+			return t.nonNull[obj]
+		}
+	}
+	return false
+}
+
+// isBoxed informa se o identificador se refere a uma variável guardada em Ref
+func (t *Transpiler) isBoxed(id *ast.Ident) bool {
+	obj := t.objectOf(id)
+	return obj != nil && t.boxed[obj]
+}
+
+// pointerElem devolve o tipo apontado, se a expressão for um ponteiro
+func (t *Transpiler) pointerElem(expr ast.Expr) types.Type {
+	typ := t.typeOf(expr)
+	if typ == nil {
+		return nil
+	}
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return nil
+}
+
+// isStructType informa se o tipo (ou seu tipo subjacente) é uma struct
+func isStructType(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	_, ok := typ.Underlying().(*types.Struct)
+	return ok
+}
+
+// writeNonNull acrescenta !! quando a expressão pode ser nil
+func (t *Transpiler) writeNonNull(expr ast.Expr) {
+	if t.mayBeNull(expr) {
+		t.write("!!")
+	}
+}
+
+// mayBeNull informa se a expressão pode ser nil: ponteiros e variáveis ou
+// campos declarados anuláveis por terem null como valor zero
+func (t *Transpiler) mayBeNull(expr ast.Expr) bool {
+	if t.pointerElem(expr) != nil && !t.isNonNullExpr(expr) {
+		return true
+	}
+	var obj types.Object
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		obj = t.objectOf(e)
+	case *ast.SelectorExpr:
+		if t.info != nil {
+			if s, ok := t.info.Selections[e]; ok && s.Kind() == types.FieldVal {
+				obj = s.Obj()
+			}
+		}
+	}
+	return obj != nil && t.nullable[obj]
+}
+
+// mayBeNilInterface informa se a expressão é uma interface anulável no
+// Kotlin: variáveis, parâmetros e resultados de funções de tipo interface
+// (ex: err.Error() vira err!!.Error())
+func (t *Transpiler) mayBeNilInterface(expr ast.Expr) bool {
+	typ := t.typeOf(expr)
+	if typ == nil || !types.IsInterface(typ) || t.isNonNullExpr(expr) {
+		return false
+	}
+	if _, isParam := typ.(*types.TypeParam); isParam {
+		return false
+	}
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.CallExpr:
+		// Conversões como error(e) mantêm o valor convertido
+		return !t.info.Types[e.Fun].IsType()
+	case *ast.Ident:
+		_, isVar := t.objectOf(e).(*types.Var)
+		return isVar
+	}
+	return false
+}
+
+// transpileNew traduz new(T): structs viram uma instância com valores zero e
+// os demais tipos viram uma caixa Ref com o valor zero
+func (t *Transpiler) transpileNew(call *ast.CallExpr) bool {
+	id, ok := call.Fun.(*ast.Ident)
+	if !ok || id.Name != "new" || len(call.Args) != 1 || t.info == nil {
+		return false
+	}
+	if _, isBuiltin := t.objectOf(id).(*types.Builtin); !isBuiltin {
+		return false
+	}
+	typ := t.typeOf(call.Args[0])
+	if typ == nil {
+		return false
+	}
+	if isStructType(typ) {
+		t.write(t.zeroValue(typ))
+		return true
+	}
+	t.useRuntime("Ref")
+	t.write("Ref<" + t.resolveGoType(typ) + ">(" + t.zeroValue(typ) + ")")
+	return true
+}
+
+// transpileAssignedValue escreve o valor atribuído a uma variável, envolvendo
+// em Ref quando a variável é uma caixa e copiando structs, já que no Go a
+// atribuição copia o valor enquanto no Kotlin compartilharia o objeto
+func (t *Transpiler) transpileAssignedValue(lhs ast.Expr, rhs ast.Expr, define bool) {
+	boxed := false
+	if id, ok := lhs.(*ast.Ident); ok && define && t.isBoxed(id) {
+		boxed = true
+		t.write("Ref(")
+	}
+	t.transpileCopied(rhs)
+	if boxed {
+		t.write(")")
+	}
+}
+
+// transpileCopied escreve a expressão, copiando structs e arrays endereçáveis
+func (t *Transpiler) transpileCopied(expr ast.Expr) {
+	t.Transpile(expr)
+	if t.needsCopy(expr) {
+		t.write(t.copySuffix(t.typeOf(expr)))
+	}
+}
+
+// needsCopy informa se a expressão é uma struct ou array endereçável que precisa ser copiada
+func (t *Transpiler) needsCopy(expr ast.Expr) bool {
+	typ := t.typeOf(expr)
+	if typ == nil || t.copySuffix(typ) == "" {
+		return false
+	}
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		_, isVar := t.objectOf(e).(*types.Var)
+		return isVar
+	case *ast.SelectorExpr:
+		_, isField := t.info.Selections[e]
+		return isField
+	case *ast.IndexExpr, *ast.StarExpr:
+		return true
+	}
+	return false
+}
+
+// copySuffix devolve o sufixo que copia um valor do tipo como no Go: copy()
+// para structs, refazendo também os campos struct e array aninhados, e uma
+// nova lista para arrays. Devolve "" para tipos que não precisam de cópia.
+func (t *Transpiler) copySuffix(typ types.Type) string {
+	switch u := typ.Underlying().(type) {
+	case *types.Struct:
+		if _, ok := typ.(*types.Named); !ok {
+			return ""
+		}
+		var fields []string
+		for i := 0; i < u.NumFields(); i++ {
+			if suffix := t.copySuffix(u.Field(i).Type()); suffix != "" {
+				name := t.objName(u.Field(i))
+				fields = append(fields, name+" = it."+name+suffix)
+			}
+		}
+		if len(fields) == 0 {
+			return ".copy()"
+		}
+		return ".let { it.copy(" + strings.Join(fields, ", ") + ") }"
+	case *types.Array:
+		if suffix := t.copySuffix(u.Elem()); suffix != "" {
+			return ".mapTo(mutableListOf()) { it" + suffix + " }"
+		}
+		return ".toMutableList()"
+	}
+	return ""
+}
+
+// transpileStructStore traduz *p = v para structs, copiando campo a campo
+// para que todos os aliases do ponteiro vejam o novo valor
+func (t *Transpiler) transpileStructStore(star *ast.StarExpr, rhs ast.Expr) bool {
+	elem := t.pointerElem(star.X)
+	if elem == nil || !isStructType(elem) {
+		return false
+	}
+	st := elem.Underlying().(*types.Struct)
+	dst, src := t.newTemp(), t.newTemp()
+	t.write("run {\n")
+	t.indent()
+	t.writeIndent()
+	t.write("val " + dst + " = ")
+	t.Transpile(star.X)
+	t.writeNonNull(star.X)
+	t.write("\n")
+	t.writeIndent()
+	t.write("val " + src + " = ")
+	t.Transpile(rhs)
+	t.write("\n")
+	for i := 0; i < st.NumFields(); i++ {
+		name := t.objName(st.Field(i))
+		t.writeLine(dst + "." + name + " = " + src + "." + name + t.copySuffix(st.Field(i).Type()))
+	}
+	t.unindent()
+	t.writeIndent()
+	t.write("}")
+	return true
+}
+
+// mutatesReceiver informa se o método altera campos do receiver, direta ou
+// indiretamente (chamando métodos com receiver ponteiro)
+func (t *Transpiler) mutatesReceiver(fd *ast.FuncDecl) bool {
+	if t.info == nil || fd.Body == nil || len(fd.Recv.List[0].Names) == 0 {
+		return false
+	}
+	recv := t.objectOf(fd.Recv.List[0].Names[0])
+	return recv != nil && t.copySuffix(recv.Type()) != "" && t.mutatesValue(fd.Body, recv)
+}
+
+// mutatesValue informa se o corpo altera campos ou elementos da variável
+// (struct ou array), toma o endereço dela ou de uma parte ou chama nela um
+// método com receiver ponteiro: nesses casos ela precisa ser uma cópia
+func (t *Transpiler) mutatesValue(body *ast.BlockStmt, obj types.Object) bool {
+	// partOf informa se a expressão é obj.f, obj[i] ou uma composição deles;
+	// whole aceita também a própria variável
+	partOf := func(expr ast.Expr, whole bool) bool {
+		for {
+			switch x := ast.Unparen(expr).(type) {
+			case *ast.Ident:
+				return whole && t.objectOf(x) == obj
+			case *ast.SelectorExpr:
+				expr = x.X
+			case *ast.IndexExpr:
+				expr = x.X
+			default:
+				return false
+			}
+			whole = true
+		}
+	}
+	mutates := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if partOf(lhs, false) {
+					mutates = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if partOf(n.X, false) {
+				mutates = true
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && partOf(n.X, true) {
+				mutates = true
+			}
+		case *ast.CallExpr:
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if s, ok := t.info.Selections[sel]; ok && s.Kind() == types.MethodVal {
+				sig := s.Obj().Type().(*types.Signature)
+				if _, ptrRecv := sig.Recv().Type().(*types.Pointer); ptrRecv && partOf(sel.X, true) {
+					mutates = true
+				}
+			}
+		}
+		return !mutates
+	})
+	return mutates
+}
@@ -0,0 +1,260 @@
+package transpiler
+
+import (
+	"bytes"
+	"embed"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RuntimeVersion é a versão do go2kt-runtime (pasta runtime/) exigida pelo código gerado
+const RuntimeVersion = "1.1.0"
+
+// runtimePackage é o pacote Kotlin dos fontes do go2kt-runtime
+const runtimePackage = "go2kt.runtime"
+
+//go:embed runtime/*.kt
+var runtimeSources embed.FS
+
+// runtimeSymbols associa cada declaração do runtime ao arquivo que a contém
+var runtimeSymbols = map[string]string{
+	"Ref":           "Ref.kt",
+	"Complex":       "Complex.kt",
+	"cmplx":         "Cmplx.kt",
+	"strconv":       "Strconv.kt",
+	"goReplace":     "Strings.kt",
+	"goByteIndex":   "Strings.kt",
+	"goRound":       "Math.kt",
+	"sortSlice":     "Sort.kt",
+	"GoPanic":       "Panic.kt",
+	"GoError":       "Errors.kt",
+	"GoValueError":  "Errors.kt",
+	"errors":        "Errors.kt",
+	"wrapError":     "Errors.kt",
+	"goErrorf":      "Errorf.kt",
+	"io":            "Io.kt",
+	"goScan":        "Io.kt",
+	"goScanln":      "Io.kt",
+	"goScanf":       "Io.kt",
+	"goSscan":       "Io.kt",
+	"goSscanf":      "Io.kt",
+	"os":            "Os.kt",
+	"GoTimer":       "Time.kt",
+	"GoTicker":      "Time.kt",
+	"goAfter":       "Time.kt",
+	"goTick":        "Time.kt",
+	"json":          "Json.kt",
+	"GoJsonEncoder": "Json.kt",
+	"GoJsonDecoder": "Json.kt",
+	"GoJsonQuoted":  "Json.kt",
+	"panic":         "Panic.kt",
+	"recover":       "Recover.kt",
+	"runDefers":     "Recover.kt",
+	"goSprintf":     "Fmt.kt",
+	"goFormat":      "Fmt.kt",
+	"Tuple4":        "Tuples.kt",
+	"Tuple5":        "Tuples.kt",
+	"Tuple6":        "Tuples.kt",
+	"Tuple7":        "Tuples.kt",
+	"Tuple8":        "Tuples.kt",
+	"Tuple9":        "Tuples.kt",
+}
+
+// runtimeDeps são os arquivos do runtime que dependem de declarações de outros
+var runtimeDeps = map[string][]string{
+	"Strconv.kt": {"Errors.kt"},
+	"Errorf.kt":  {"Errors.kt", "Fmt.kt"},
+	"Io.kt":      {"Errors.kt"},
+	"Os.kt":      {"Errors.kt"},
+	"Json.kt":    {"Errors.kt", "Io.kt"},
+	"Recover.kt": {"Panic.kt"},
+}
+
+// runtimeDecl reconhece uma declaração de nível superior de um fonte do
+// runtime; o grupo 1 é o nome declarado (sem o receptor de extensões)
+var runtimeDecl = regexp.MustCompile(`(?m)^(?:(?:private|internal|inline|data|sealed|abstract|open|const)\s+)*(?:fun|class|object|val|var|interface|typealias)\s+(?:<[^>]*>\s*)?(?:[\w<>?, *]+\.)?(\w+)`)
+
+// runtimeNames devolve os nomes declarados no nível superior dos fontes do
+// runtime, que sem a opção Runtime são copiados para o arquivo gerado
+func runtimeNames() []string {
+	var names []string
+	entries, _ := runtimeSources.ReadDir("runtime")
+	for _, entry := range entries {
+		for _, m := range runtimeDecl.FindAllStringSubmatch(runtimeSource(entry.Name()), -1) {
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// useRuntime registra que o código gerado usa uma declaração do runtime
+func (t *Transpiler) useRuntime(symbol string) {
+	t.runtime[symbol] = true
+}
+
+// runtimeSource devolve o fonte de um arquivo do runtime
+func runtimeSource(file string) string {
+	src, err := runtimeSources.ReadFile("runtime/" + file)
+	if err != nil {
+		panic("go2kt-runtime: arquivo ausente " + file)
+	}
+	return string(src)
+}
+
+// runtimeBody devolve as declarações de um arquivo do runtime, sem o
+// cabeçalho, a linha package e os imports, para serem copiadas no próprio arquivo gerado
+func runtimeBody(file string) string {
+	src := runtimeSource(file)
+	if i := strings.Index(src, "package "+runtimePackage+"\n"); i >= 0 {
+		src = src[i+len("package "+runtimePackage+"\n"):]
+	}
+	var body []string
+	for _, line := range strings.Split(src, "\n") {
+		if !strings.HasPrefix(line, "import ") {
+			body = append(body, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(body, "\n"))
+}
+
+// runtimeImports devolve os imports de um arquivo do runtime, que no código
+// gerado sobem para o início do arquivo junto com os demais
+func runtimeImports(file string) []string {
+	var paths []string
+	for _, line := range strings.Split(runtimeSource(file), "\n") {
+		if path, ok := strings.CutPrefix(line, "import "); ok {
+			paths = append(paths, strings.TrimSpace(path))
+		}
+	}
+	return paths
+}
+
+// runtimeFiles devolve, em ordem, os arquivos do runtime usados pelo código gerado
+func (t *Transpiler) runtimeFiles() []string {
+	seen := make(map[string]bool)
+	var files []string
+	var add func(file string)
+	add = func(file string) {
+		if seen[file] {
+			return
+		}
+		seen[file] = true
+		files = append(files, file)
+		for _, dep := range runtimeDeps[file] {
+			add(dep)
+		}
+	}
+	for symbol := range t.runtime {
+		if file, ok := runtimeSymbols[symbol]; ok {
+			add(file)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// useImport registra um import do Kotlin exigido pelo código gerado (ex: kotlin.math.sqrt)
+func (t *Transpiler) useImport(path string) {
+	t.imports[path] = true
+}
+
+// writeImports insere, na posição at do código gerado, os imports do Kotlin
+// e, com a opção Runtime, os das declarações do runtime usadas. Como eles só
+// são conhecidos depois da tradução, entram no fim, antes das declarações.
+func (t *Transpiler) writeImports(at int, blankLine bool) {
+	var paths, symbols []string
+	for path := range t.imports {
+		paths = append(paths, path)
+	}
+	if t.options.Runtime {
+		for symbol := range t.runtime {
+			if _, ok := runtimeSymbols[symbol]; ok {
+				symbols = append(symbols, symbol)
+			}
+		}
+	}
+	if len(paths) == 0 && len(symbols) == 0 {
+		return
+	}
+	sort.Strings(paths)
+	sort.Strings(symbols)
+	var imports strings.Builder
+	for _, path := range paths {
+		imports.WriteString("import " + path + "\n")
+	}
+	if len(symbols) > 0 {
+		imports.WriteString("// Requer go2kt-runtime " + RuntimeVersion + "\n")
+	}
+	for _, symbol := range symbols {
+		imports.WriteString("import " + runtimePackage + "." + symbol + "\n")
+	}
+	if blankLine {
+		imports.WriteString("\n")
+	}
+	out := t.output.Bytes()
+	var b bytes.Buffer
+	b.Write(out[:at])
+	b.WriteString(imports.String())
+	b.Write(out[at:])
+	t.output = b
+}
+
+// writeRuntimeHelpers declara no fim do arquivo as partes do runtime usadas,
+// quando a opção Runtime está desligada. As tuplas são geradas apenas nos
+// tamanhos usados e o fmt vai para o GoFormat.kt com a opção GoOutput.
+func (t *Transpiler) writeRuntimeHelpers() {
+	if !t.options.Runtime {
+		for _, file := range t.runtimeFiles() {
+			if file == "Tuples.kt" || (file == "Fmt.kt" && t.options.GoOutput) {
+				continue
+			}
+			for _, path := range runtimeImports(file) {
+				t.useImport(path)
+			}
+			for _, line := range strings.Split(runtimeBody(file), "\n") {
+				t.writeLine(line)
+			}
+		}
+	}
+	t.writeTupleHelpers()
+}
+
+// writeTupleHelpers declara as data classes TupleN usadas por funções com
+// mais de três resultados (com o runtime, apenas as que ele não oferece)
+func (t *Transpiler) writeTupleHelpers() {
+	var sizes []int
+	for symbol := range t.runtime {
+		n, err := strconv.Atoi(strings.TrimPrefix(symbol, "Tuple"))
+		if err != nil || !strings.HasPrefix(symbol, "Tuple") {
+			continue
+		}
+		if _, inRuntime := runtimeSymbols[symbol]; inRuntime && t.options.Runtime {
+			continue
+		}
+		sizes = append(sizes, n)
+	}
+	sort.Ints(sizes)
+	for _, n := range sizes {
+		var params, props []string
+		for i := 0; i < n; i++ {
+			param := "T" + strconv.Itoa(i+1)
+			params = append(params, "out "+param)
+			props = append(props, "val "+tupleField(i)+": "+param)
+		}
+		t.writeLine("data class Tuple" + strconv.Itoa(n) + "<" + strings.Join(params, ", ") + ">(" + strings.Join(props, ", ") + ")")
+	}
+}
+
+// runtimeSupportFiles devolve os fontes do runtime usados, com a versão,
+// para acompanhar a saída quando a opção Runtime está ligada
+func (t *Transpiler) runtimeSupportFiles(files map[string]string) {
+	used := t.runtimeFiles()
+	if len(used) == 0 {
+		return
+	}
+	for _, file := range append(used, "Version.kt") {
+		files["go2kt-runtime/"+file] = runtimeSource(file)
+	}
+}
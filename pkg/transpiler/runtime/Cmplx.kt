@@ -0,0 +1,50 @@
+// go2kt-runtime 1.1.0: funções do pacote math/cmplx
+package go2kt.runtime
+
+@Suppress("ClassName", "FunctionName")
+object cmplx {
+    fun Abs(x: Complex): Double = Math.hypot(x.re, x.im)
+    fun Phase(x: Complex): Double = Math.atan2(x.im, x.re)
+    fun Polar(x: Complex): Pair<Double, Double> = Pair(Abs(x), Phase(x))
+    fun Rect(r: Double, theta: Double): Complex = Complex(r * Math.cos(theta), r * Math.sin(theta))
+    fun Conj(x: Complex): Complex = Complex(x.re, -x.im)
+    fun Inf(): Complex = Complex(Double.POSITIVE_INFINITY, Double.POSITIVE_INFINITY)
+    fun NaN(): Complex = Complex(Double.NaN, Double.NaN)
+    fun IsInf(x: Complex): Boolean = x.re.isInfinite() || x.im.isInfinite()
+    fun IsNaN(x: Complex): Boolean = !IsInf(x) && (x.re.isNaN() || x.im.isNaN())
+
+    fun Sqrt(x: Complex): Complex {
+        if (x.im == 0.0) {
+            if (x.re >= 0) return Complex(Math.sqrt(x.re), x.im)
+            return Complex(0.0, Math.copySign(Math.sqrt(-x.re), x.im))
+        }
+        val r = Abs(x)
+        return Complex(Math.sqrt((r + x.re) / 2), Math.copySign(Math.sqrt((r - x.re) / 2), x.im))
+    }
+
+    fun Exp(x: Complex): Complex {
+        val r = Math.exp(x.re)
+        return Complex(r * Math.cos(x.im), r * Math.sin(x.im))
+    }
+
+    fun Log(x: Complex): Complex = Complex(Math.log(Abs(x)), Phase(x))
+    fun Log10(x: Complex): Complex = Log(x) * Complex(1 / Math.log(10.0), 0.0)
+
+    fun Pow(x: Complex, y: Complex): Complex {
+        if (x.re == 0.0 && x.im == 0.0) {
+            return when {
+                y.re == 0.0 && y.im == 0.0 -> Complex(1.0, 0.0)
+                y.re < 0 -> Inf()
+                else -> Complex(0.0, 0.0)
+            }
+        }
+        return Exp(y * Log(x))
+    }
+
+    fun Sin(x: Complex): Complex = Complex(Math.sin(x.re) * Math.cosh(x.im), Math.cos(x.re) * Math.sinh(x.im))
+    fun Cos(x: Complex): Complex = Complex(Math.cos(x.re) * Math.cosh(x.im), -Math.sin(x.re) * Math.sinh(x.im))
+    fun Tan(x: Complex): Complex = Sin(x) / Cos(x)
+    fun Sinh(x: Complex): Complex = Complex(Math.sinh(x.re) * Math.cos(x.im), Math.cosh(x.re) * Math.sin(x.im))
+    fun Cosh(x: Complex): Complex = Complex(Math.cosh(x.re) * Math.cos(x.im), Math.sinh(x.re) * Math.sin(x.im))
+    fun Tanh(x: Complex): Complex = Sinh(x) / Cosh(x)
+}
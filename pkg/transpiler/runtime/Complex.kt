@@ -0,0 +1,36 @@
+// go2kt-runtime 1.1.0: complex64 e complex128
+package go2kt.runtime
+
+// Uma value class do Kotlin guarda uma única propriedade; o par (re, im) é
+// uma data class imutável, com a mesma semântica de valor do Go
+data class Complex(val re: Double, val im: Double) {
+    operator fun plus(o: Complex) = Complex(re + o.re, im + o.im)
+    operator fun minus(o: Complex) = Complex(re - o.re, im - o.im)
+    operator fun times(o: Complex) = Complex(re * o.re - im * o.im, re * o.im + im * o.re)
+    operator fun unaryMinus() = Complex(-re, -im)
+
+    // Algoritmo de Smith, que evita overflow no denominador
+    operator fun div(o: Complex): Complex {
+        if (Math.abs(o.re) >= Math.abs(o.im)) {
+            val ratio = o.im / o.re
+            val denom = o.re + o.im * ratio
+            return Complex((re + im * ratio) / denom, (im - re * ratio) / denom)
+        }
+        val ratio = o.re / o.im
+        val denom = o.im + o.re * ratio
+        return Complex((re * ratio + im) / denom, (im * ratio - re) / denom)
+    }
+
+    // Mesmo formato do fmt do Go: (1+2i)
+    override fun toString(): String {
+        val imag = complexPart(im)
+        return "(" + complexPart(re) + (if (imag.startsWith("-") || imag.startsWith("+")) "" else "+") + imag + "i)"
+    }
+}
+
+private fun complexPart(d: Double): String = when {
+    d.isNaN() -> "NaN"
+    d.isInfinite() -> if (d > 0) "+Inf" else "-Inf"
+    d == Math.rint(d) && Math.abs(d) < 1e21 -> (if (1 / d < 0) "-" else "") + Math.abs(d).toLong()
+    else -> d.toString()
+}
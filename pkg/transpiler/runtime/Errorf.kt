@@ -0,0 +1,28 @@
+// go2kt-runtime 1.1.0: fmt.Errorf com formato dinâmico
+package go2kt.runtime
+
+// Formata como o fmt.Errorf do Go e embrulha os argumentos dos verbos %w
+fun goErrorf(format: String, vararg args: Any?): GoError {
+    val wrapped = mutableListOf<GoError?>()
+    var next = 0
+    var i = 0
+    while (i < format.length) {
+        if (format[i++] != '%') {
+            continue
+        }
+        while (i < format.length && (format[i] in "+-# 0.*[]" || format[i].isDigit())) {
+            if (format[i] == '*') next++
+            if (format[i] == '[') next = (format.substring(i + 1, format.indexOf(']', i).coerceAtLeast(i + 1)).toIntOrNull() ?: 1) - 1
+            i++
+        }
+        if (i >= format.length) {
+            break
+        }
+        when (format[i++]) {
+            '%' -> {}
+            'w' -> wrapped.add(args.getOrNull(next++) as? GoError)
+            else -> next++
+        }
+    }
+    return wrapError(goSprintf(format, *args), *wrapped.toTypedArray())
+}
@@ -0,0 +1,79 @@
+// go2kt-runtime 1.1.0: interface error e pacote errors
+package go2kt.runtime
+
+// Interface error do Go. Os erros são exceções, para poderem ser lançados
+// por panic e encadeados (cause) como os embrulhados por fmt.Errorf com %w
+abstract class GoError : Exception() {
+    abstract fun Error(): String
+
+    // Erro embrulhado; tipos do usuário com Unwrap() error o sobrescrevem
+    open fun Unwrap(): GoError? = null
+
+    // Equivalência extra usada por errors.Is, como o método Is(error) bool do Go
+    open fun Is(target: GoError?): Boolean = false
+
+    final override val message: String get() = Error()
+    override val cause: Throwable? get() = Unwrap()
+    final override fun toString(): String = Error()
+}
+
+// Marca os tipos do usuário com Error() de receiver por valor: errors.Is os
+// compara pelo conteúdo, como o == do Go; os demais são ponteiros e só são
+// iguais ao mesmo objeto
+interface GoValueError
+
+private class ErrorString(private val text: String) : GoError() {
+    override fun Error(): String = text
+}
+
+// Erro de fmt.Errorf com %w ou de errors.Join: embrulha um ou mais erros
+class WrapError(private val text: String, val errs: List<GoError>) : GoError() {
+    override fun Error(): String = text
+    override fun Unwrap(): GoError? = errs.singleOrNull()
+}
+
+// Erro com a mensagem já formatada que embrulha os argumentos de %w
+fun wrapError(text: String, vararg wrapped: GoError?): GoError {
+    val errs = wrapped.filterNotNull()
+    return if (errs.isEmpty()) errors.New(text) else WrapError(text, errs)
+}
+
+@Suppress("ClassName", "FunctionName")
+object errors {
+    fun New(text: String): GoError = ErrorString(text)
+
+    fun Unwrap(err: GoError?): GoError? = err?.Unwrap()
+
+    fun Is(err: GoError?, target: GoError?): Boolean {
+        if (err == null || target == null) {
+            return err === target
+        }
+        return chain(err).any { it === target || (it is GoValueError && it == target) || it.Is(target) }
+    }
+
+    // errors.As(err, &target) vira errors.As<T>(err) { target = it }
+    inline fun <reified T> As(err: GoError?, set: (T) -> Unit): Boolean {
+        val found = chain(err).firstOrNull { it is T } ?: return false
+        set(found as T)
+        return true
+    }
+
+    fun Join(vararg errs: GoError?): GoError? {
+        val list = errs.filterNotNull()
+        return if (list.isEmpty()) null else WrapError(list.joinToString("\n") { it.Error() }, list)
+    }
+
+    // Percorre a árvore de erros embrulhados em pré-ordem, como errors.Is e errors.As
+    fun chain(err: GoError?): List<GoError> {
+        val out = mutableListOf<GoError>()
+        val pending = ArrayDeque<GoError>()
+        err?.let { pending.add(it) }
+        while (pending.isNotEmpty()) {
+            val e = pending.removeFirst()
+            out.add(e)
+            val children = if (e is WrapError) e.errs else listOfNotNull(e.Unwrap())
+            children.asReversed().forEach { pending.addFirst(it) }
+        }
+        return out
+    }
+}
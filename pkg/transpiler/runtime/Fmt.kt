@@ -0,0 +1,317 @@
+// go2kt-runtime 1.1.0: formatação do pacote fmt (Sprintf e %v)
+package go2kt.runtime
+
+fun goSprintf(format: String, vararg args: Any?): String {
+    val out = StringBuilder()
+    var next = 0
+    var i = 0
+    while (i < format.length) {
+        if (format[i] != '%') {
+            out.append(format[i++])
+            continue
+        }
+        i++
+        var flags = ""
+        while (i < format.length && format[i] in "+-# 0") flags += format[i++]
+        if (i < format.length && format[i] == '[') {
+            val end = format.indexOf(']', i)
+            if (end > 0) {
+                next = (format.substring(i + 1, end).toIntOrNull() ?: 1) - 1
+                i = end + 1
+            }
+        }
+        var width = -1
+        if (i < format.length && format[i] == '*') {
+            width = (args.getOrNull(next++) as? Number)?.toInt() ?: 0
+            i++
+        } else {
+            while (i < format.length && format[i].isDigit()) width = maxOf(width, 0) * 10 + (format[i++] - '0')
+        }
+        var precision = -1
+        if (i < format.length && format[i] == '.') {
+            i++
+            precision = 0
+            if (i < format.length && format[i] == '*') {
+                precision = (args.getOrNull(next++) as? Number)?.toInt() ?: 0
+                i++
+            } else {
+                while (i < format.length && format[i].isDigit()) precision = precision * 10 + (format[i++] - '0')
+            }
+        }
+        if (i >= format.length) {
+            out.append("%!(NOVERB)")
+            break
+        }
+        // %w (fmt.Errorf) imprime o erro como %v
+        val verb = format[i++].let { if (it == 'w') 'v' else it }
+        when {
+            verb == '%' -> out.append('%')
+            next >= args.size -> out.append("%!$verb(MISSING)")
+            else -> out.append(goFormatVerb(verb, flags, width, precision, args[next++]))
+        }
+    }
+    if (next < args.size) out.append("%!(EXTRA ").append(args.drop(next).joinToString(", ")).append(")")
+    return out.toString()
+}
+
+private fun goFormatVerb(verb: Char, flags: String, width: Int, precision: Int, arg: Any?): String {
+    // Value classes e enums (type Meters int) são formatados pelo valor, exceto em %v e %s
+    val raw = goUnwrap(arg)
+    val integer = goInteger(raw)
+    val number = integer != null || raw is Double || raw is Float
+    var sign = ""
+    var prefix = ""
+    val body = when {
+        verb == 'T' -> goTypeName(arg)
+        verb == 'p' -> "0x" + Integer.toHexString(System.identityHashCode(arg))
+        arg == null -> if (verb == 'v' || verb == 's') "<nil>" else "%!$verb(<nil>)"
+        raw !== arg && (verb == 'v' || verb == 's') -> goFormat(arg, flags)
+        integer != null && verb in "vdxXobcqU" -> when (verb) {
+            'c' -> String(Character.toChars(integer.toInt()))
+            'q' -> goQuote(String(Character.toChars(integer.toInt())), '\'')
+            'U' -> "U+" + integer.toString(16).uppercase().padStart(4, '0')
+            else -> {
+                val radix = when (verb) {
+                    'x', 'X' -> 16
+                    'o' -> 8
+                    'b' -> 2
+                    else -> 10
+                }
+                if (integer.signum() < 0) sign = "-"
+                if ('#' in flags) prefix = when (verb) {
+                    'x' -> "0x"
+                    'X' -> "0X"
+                    'o' -> "0"
+                    'b' -> "0b"
+                    else -> ""
+                }
+                val digits = integer.abs().toString(radix).padStart(precision, '0')
+                if (verb == 'X') digits.uppercase() else digits
+            }
+        }
+        // Complexos formatam as duas partes com o verbo, a imaginária sempre com sinal: (3.00+2.00i)
+        arg.javaClass.simpleName == "Complex" && verb in "veEfFgG" -> {
+            val (re, im) = listOf("re", "im").map { name ->
+                arg.javaClass.getDeclaredField(name).let {
+                    it.isAccessible = true
+                    it.getDouble(arg)
+                }
+            }
+            return "(" + goFormatVerb(verb, flags, width, precision, re) +
+                goFormatVerb(verb, flags.replace(" ", "") + "+", width, precision, im) + "i)"
+        }
+        (raw is Double || raw is Float) && verb in "veEfFgG" -> {
+            val d = (raw as Number).toDouble()
+            if (d < 0 || (d == 0.0 && 1 / d < 0)) sign = "-" else if (d.isInfinite()) sign = "+"
+            goFormatFloat(Math.abs(d), if (verb == 'v') 'g' else verb, precision, raw is Float)
+        }
+        raw is Boolean && verb in "vt" -> raw.toString()
+        raw is String && verb in "vsqxX" -> when (verb) {
+            'q' -> goQuote(raw, '"')
+            'x', 'X' -> {
+                val hex = raw.toByteArray().joinToString(if (' ' in flags) " " else "") { "%02x".format(it) }
+                if (verb == 'X') hex.uppercase() else hex
+            }
+            else -> if (verb == 'v' && '#' in flags) goQuote(raw, '"') else if (precision >= 0 && precision < raw.codePointCount(0, raw.length)) raw.substring(0, raw.offsetByCodePoints(0, precision)) else raw
+        }
+        verb == 'v' || verb == 's' -> goFormat(arg, flags)
+        else -> "%!$verb(" + goTypeName(arg) + "=" + goFormat(arg) + ")"
+    }
+    if (sign.isEmpty() && number && verb !in "cqUTp") {
+        if ('+' in flags) sign = "+" else if (' ' in flags) sign = " "
+    }
+    val text = sign + prefix + body
+    val padding = width - text.codePointCount(0, text.length)
+    return when {
+        padding <= 0 -> text
+        '-' in flags -> text + " ".repeat(padding)
+        '0' in flags && number -> sign + prefix + "0".repeat(padding) + body
+        else -> " ".repeat(padding) + text
+    }
+}
+
+private fun goFormatFloat(d: Double, verb: Char, precision: Int, single: Boolean): String {
+    if (d.isNaN()) return "NaN"
+    if (d.isInfinite()) return "Inf"
+    val digits = if (precision < 0) 6 else precision
+    return when (verb) {
+        'f', 'F' -> String.format(java.util.Locale.ROOT, "%.${digits}f", d)
+        'e', 'E' -> String.format(java.util.Locale.ROOT, "%.${digits}e", d).let { if (verb == 'E') it.uppercase() else it }
+        else -> {
+            if (d == 0.0) return "0"
+            // %g usa a menor representação exata e expoente fora de [-4, 21)
+            var decimal = java.math.BigDecimal(if (single) d.toFloat().toString() else d.toString())
+            if (precision >= 0) decimal = decimal.round(java.math.MathContext(maxOf(precision, 1)))
+            decimal = decimal.stripTrailingZeros()
+            val unscaled = decimal.unscaledValue().toString()
+            val exponent = unscaled.length - 1 - decimal.scale()
+            val limit = if (precision >= 0) maxOf(precision, 1) else 21
+            if (exponent < -4 || exponent >= limit) {
+                val mantissa = if (unscaled.length > 1) "${unscaled[0]}.${unscaled.substring(1)}" else unscaled
+                mantissa + (if (verb == 'G') "E" else "e") + (if (exponent < 0) "-" else "+") + Math.abs(exponent).toString().padStart(2, '0')
+            } else {
+                decimal.toPlainString()
+            }
+        }
+    }
+}
+
+// goUnwrap devolve os nanossegundos de uma Duration e o valor guardado em uma
+// value class (que tem o método estático box-impl) ou em uma enum class
+// gerada de constantes do Go
+private fun goUnwrap(arg: Any?): Any? {
+    if (arg is kotlin.time.Duration) return arg.inWholeNanoseconds
+    if (arg == null || !(arg is Enum<*> || arg.javaClass.declaredMethods.any { it.name == "box-impl" })) return arg
+    val field = arg.javaClass.declaredFields.firstOrNull { it.name == "value" } ?: return arg
+    field.isAccessible = true
+    return field.get(arg)
+}
+
+private fun goInteger(arg: Any?): java.math.BigInteger? = when (arg) {
+    is Int -> java.math.BigInteger.valueOf(arg.toLong())
+    is Long -> java.math.BigInteger.valueOf(arg)
+    is Short -> java.math.BigInteger.valueOf(arg.toLong())
+    is Byte -> java.math.BigInteger.valueOf(arg.toLong())
+    is UInt -> java.math.BigInteger.valueOf(arg.toLong())
+    is UShort -> java.math.BigInteger.valueOf(arg.toLong())
+    is UByte -> java.math.BigInteger.valueOf(arg.toLong())
+    is ULong -> java.math.BigInteger(arg.toString())
+    is Char -> java.math.BigInteger.valueOf(arg.code.toLong())
+    else -> null
+}
+
+private fun goQuote(s: String, quote: Char): String {
+    val out = StringBuilder().append(quote)
+    var i = 0
+    while (i < s.length) {
+        val cp = s.codePointAt(i)
+        i += Character.charCount(cp)
+        when {
+            cp == quote.code || cp == '\\'.code -> out.append('\\').appendCodePoint(cp)
+            cp == '\n'.code -> out.append("\\n")
+            cp == '\t'.code -> out.append("\\t")
+            cp == '\r'.code -> out.append("\\r")
+            cp < 0x20 || cp == 0x7f -> out.append("\\x").append(cp.toString(16).padStart(2, '0'))
+            else -> out.appendCodePoint(cp)
+        }
+    }
+    return out.append(quote).toString()
+}
+
+private fun goTypeName(arg: Any?): String = when (arg) {
+    null -> "<nil>"
+    is Int -> "int"
+    is Long -> "int64"
+    is Short -> "int16"
+    is Byte -> "int8"
+    is UInt -> "uint"
+    is ULong -> "uint64"
+    is UShort -> "uint16"
+    is UByte -> "uint8"
+    is Double -> "float64"
+    is Float -> "float32"
+    is Boolean -> "bool"
+    is String -> "string"
+    is Char -> "int32"
+    else -> arg::class.simpleName ?: "?"
+}
+
+fun goFormat(v: Any?, flags: String = "", pointer: Boolean = false): String {
+    val sharp = '#' in flags
+    return when (v) {
+        null -> if (sharp) "nil" else "<nil>"
+        is String -> if (sharp) goQuote(v, '"') else v
+        is Char -> v.code.toString()
+        is Double, is Float -> goFormatVerb('v', "", -1, -1, v)
+        is Number, is Boolean, is UInt, is ULong, is UShort, is UByte -> v.toString()
+        is Enum<*> -> v.toString()
+        is Throwable -> v.message ?: ""
+        is kotlin.time.Duration -> goDuration(v)
+        is java.time.Instant -> v.toString()
+        is Function<*> -> goAddress(v)
+        is Map<*, *> -> {
+            val entries = v.entries.sortedWith { a, b -> goCompare(a.key, b.key) }
+            if (sharp) {
+                val first = entries.firstOrNull()
+                "map[" + goTypeName(first?.key) + "]" + goTypeName(first?.value) + "{" +
+                    entries.joinToString(", ") { goFormat(it.key, flags) + ":" + goFormat(it.value, flags) } + "}"
+            } else {
+                "map[" + entries.joinToString(" ") { goFormat(it.key, flags) + ":" + goFormat(it.value, flags) } + "]"
+            }
+        }
+        is Collection<*> -> goFormatList(v.toList(), flags)
+        is Array<*> -> goFormatList(v.toList(), flags)
+        else -> {
+            val type = v.javaClass
+            val fields = type.declaredFields.filter {
+                !java.lang.reflect.Modifier.isStatic(it.modifiers) && !it.isSynthetic && !it.name.startsWith("$")
+            }
+            when {
+                type.simpleName == "Ref" -> goAddress(v)
+                type.simpleName == "Complex" -> v.toString()
+                type.name.startsWith("java.") || type.name.startsWith("kotlin") -> goAddress(v)
+                // Value classes e classes sem copy (não geradas de structs) usam o próprio toString
+                fields.isNotEmpty() && type.declaredMethods.none { it.name == "copy" } -> v.toString()
+                else -> {
+                    val values = fields.map {
+                        it.isAccessible = true
+                        val value = goFormat(it.get(v), flags)
+                        if ('+' in flags || sharp) it.name + ":" + value else value
+                    }
+                    val struct = if (sharp) type.simpleName + "{" + values.joinToString(", ") + "}" else "{" + values.joinToString(" ") + "}"
+                    if (pointer) "&$struct" else struct
+                }
+            }
+        }
+    }
+}
+
+// goDuration imprime a duração como o Duration.String do Go (2m30s, 1.5ms, 0s)
+private fun goDuration(d: kotlin.time.Duration): String {
+    val ns = d.inWholeNanoseconds
+    if (ns == 0L) return "0s"
+    val sign = if (ns < 0) "-" else ""
+    val abs = Math.abs(ns)
+    if (abs < 1_000_000_000L) {
+        return sign + when {
+            abs < 1_000L -> "${abs}ns"
+            abs < 1_000_000L -> goFraction(abs, 1_000L) + "µs"
+            else -> goFraction(abs, 1_000_000L) + "ms"
+        }
+    }
+    val hours = abs / 3_600_000_000_000L
+    val minutes = abs / 60_000_000_000L % 60
+    val seconds = goFraction(abs % 60_000_000_000L, 1_000_000_000L) + "s"
+    return sign + when {
+        hours > 0 -> "${hours}h${minutes}m$seconds"
+        minutes > 0 -> "${minutes}m$seconds"
+        else -> seconds
+    }
+}
+
+// goFraction escreve v / scale com os decimais necessários, sem zeros à direita
+private fun goFraction(v: Long, scale: Long): String {
+    val fraction = v % scale
+    if (fraction == 0L) return (v / scale).toString()
+    val digits = fraction.toString().padStart(scale.toString().length - 1, '0').trimEnd('0')
+    return "${v / scale}.$digits"
+}
+
+private fun goFormatList(items: List<Any?>, flags: String): String =
+    if ('#' in flags) "[]" + goTypeName(items.firstOrNull()) + "{" + items.joinToString(", ") { goFormat(it, flags) } + "}"
+    else "[" + items.joinToString(" ") { goFormat(it, flags) } + "]"
+
+private fun goAddress(v: Any): String = "0x" + Integer.toHexString(System.identityHashCode(v))
+
+// Chaves de map são impressas em ordem, como no Go: números pelo valor, textos e booleanos pela ordem natural
+private fun goCompare(a: Any?, b: Any?): Int {
+    val x = goInteger(a)
+    val y = goInteger(b)
+    return when {
+        x != null && y != null -> x.compareTo(y)
+        a is Number && b is Number -> a.toDouble().compareTo(b.toDouble())
+        a is String && b is String -> a.compareTo(b)
+        a is Boolean && b is Boolean -> a.compareTo(b)
+        else -> goFormat(a).compareTo(goFormat(b))
+    }
+}
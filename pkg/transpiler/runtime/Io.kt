@@ -0,0 +1,129 @@
+// go2kt-runtime 1.1.0: entrada padrão (fmt.Scan*, fmt.Sscan*) e io.EOF
+package go2kt.runtime
+
+@Suppress("ClassName")
+object io {
+    val EOF: GoError = errors.New("EOF")
+}
+
+// Palavras já lidas da linha atual da entrada padrão e ainda não consumidas
+private val goStdinTokens = ArrayDeque<String>()
+
+private fun goTokens(text: String): List<String> = text.split(Regex("\\s+")).filter { it.isNotEmpty() }
+
+// Lê a próxima palavra da entrada padrão, atravessando linhas como fmt.Scan
+private fun goNextToken(): String? {
+    while (goStdinTokens.isEmpty()) {
+        val line = readLine() ?: return null
+        goStdinTokens.addAll(goTokens(line))
+    }
+    return goStdinTokens.removeFirst()
+}
+
+// Resto da linha atual (ou a próxima linha), usado por fmt.Scanln e fmt.Scanf
+private fun goRestOfLine(): String? {
+    if (goStdinTokens.isEmpty()) {
+        return readLine()
+    }
+    val rest = goStdinTokens.joinToString(" ")
+    goStdinTokens.clear()
+    return rest
+}
+
+// Atribui as palavras aos destinos; cada destino converte a palavra para o tipo da variável
+private fun goAssign(words: Iterator<String>, targets: Array<out (String) -> Unit>, eof: () -> GoError): Pair<Int, GoError?> {
+    var n = 0
+    for (target in targets) {
+        if (!words.hasNext()) {
+            return Pair(n, eof())
+        }
+        val word = words.next()
+        try {
+            target(word)
+        } catch (e: IllegalArgumentException) {
+            return Pair(n, errors.New("strconv: parsing \"$word\": invalid syntax"))
+        }
+        n++
+    }
+    return Pair(n, null)
+}
+
+// fmt.Scan(&a, &b) vira goScan({ a = it.toInt() }, { b = it })
+fun goScan(vararg targets: (String) -> Unit): Pair<Int, GoError?> {
+    var read = 0
+    val words = generateSequence { goNextToken() }.onEach { read++ }.iterator()
+    return goAssign(words, targets) { if (read == 0) io.EOF else errors.New("unexpected EOF") }
+}
+
+fun goScanln(vararg targets: (String) -> Unit): Pair<Int, GoError?> {
+    val line = goRestOfLine() ?: return Pair(0, io.EOF)
+    val words = goTokens(line)
+    val result = goAssign(words.iterator(), targets) { errors.New("unexpected newline") }
+    if (result.second == null && words.size > targets.size) {
+        return Pair(result.first, errors.New("expected newline"))
+    }
+    return result
+}
+
+fun goSscan(text: String, vararg targets: (String) -> Unit): Pair<Int, GoError?> =
+    goAssign(goTokens(text).iterator(), targets) { if (text.isBlank()) io.EOF else errors.New("unexpected EOF") }
+
+fun goScanf(format: String, vararg targets: (String) -> Unit): Pair<Int, GoError?> {
+    val line = goRestOfLine() ?: return Pair(0, io.EOF)
+    return goSscanf(line, format, *targets)
+}
+
+// O formato vira uma expressão regular com um grupo por verbo; brancos no
+// formato aceitam qualquer quantidade de brancos, como no Go
+fun goSscanf(text: String, format: String, vararg targets: (String) -> Unit): Pair<Int, GoError?> {
+    val pattern = StringBuilder()
+    val radixes = mutableListOf<Int>()
+    var i = 0
+    while (i < format.length) {
+        val c = format[i++]
+        when {
+            c.isWhitespace() -> pattern.append("\\s*")
+            c != '%' -> pattern.append(Regex.escape(c.toString()))
+            i < format.length && format[i] == '%' -> {
+                pattern.append("%")
+                i++
+            }
+            else -> {
+                while (i < format.length && (format[i].isDigit() || format[i] in "+-# ")) i++
+                if (i >= format.length) {
+                    return Pair(0, errors.New("bad verb in format"))
+                }
+                val verb = format[i++]
+                radixes.add(
+                    when (verb) {
+                        'x', 'X' -> 16
+                        'o' -> 8
+                        'b' -> 2
+                        else -> 10
+                    }
+                )
+                pattern.append(
+                    when (verb) {
+                        'd' -> "\\s*([+-]?\\d+)"
+                        'x', 'X' -> "\\s*([+-]?[0-9a-fA-F]+)"
+                        'o' -> "\\s*([+-]?[0-7]+)"
+                        'b' -> "\\s*([+-]?[01]+)"
+                        'e', 'E', 'f', 'F', 'g', 'G' -> "\\s*([+-]?(?:\\d+\\.?\\d*|\\.\\d+)(?:[eE][+-]?\\d+)?)"
+                        't' -> "\\s*(true|false)"
+                        'c' -> "(.)"
+                        else -> "\\s*(\\S+)"
+                    }
+                )
+            }
+        }
+    }
+    val match = Regex(pattern.toString()).find(text)
+    if (match == null || match.range.first != 0) {
+        return Pair(0, if (text.isBlank()) io.EOF else errors.New("input does not match format"))
+    }
+    // Inteiros em outras bases chegam aos destinos já em decimal
+    val words = match.groupValues.drop(1).mapIndexed { k, word ->
+        if (radixes[k] == 10) word else word.toBigInteger(radixes[k]).toString()
+    }
+    return goAssign(words.iterator(), targets) { errors.New("unexpected EOF") }
+}
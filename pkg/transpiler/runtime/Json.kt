@@ -0,0 +1,163 @@
+// go2kt-runtime 1.1.0: pacote encoding/json sobre o kotlinx.serialization
+package go2kt.runtime
+
+import kotlinx.serialization.ExperimentalSerializationApi
+import kotlinx.serialization.KSerializer
+import kotlinx.serialization.decodeFromString
+import kotlinx.serialization.descriptors.PrimitiveKind
+import kotlinx.serialization.descriptors.PrimitiveSerialDescriptor
+import kotlinx.serialization.encodeToString
+import kotlinx.serialization.encoding.Decoder
+import kotlinx.serialization.encoding.Encoder
+import kotlinx.serialization.json.DecodeSequenceMode
+import kotlinx.serialization.json.Json
+import kotlinx.serialization.json.JsonArray
+import kotlinx.serialization.json.JsonElement
+import kotlinx.serialization.json.JsonNull
+import kotlinx.serialization.json.JsonObject
+import kotlinx.serialization.json.JsonPrimitive
+import kotlinx.serialization.json.booleanOrNull
+import kotlinx.serialization.json.decodeFromJsonElement
+import kotlinx.serialization.json.decodeToSequence
+import kotlinx.serialization.json.double
+
+@Suppress("ClassName", "FunctionName")
+object json {
+    // Como no encoding/json, todos os campos são escritos (os omitempty são
+    // marcados com @EncodeDefault(NEVER)) e chaves desconhecidas são ignoradas
+    val format = Json {
+        encodeDefaults = true
+        ignoreUnknownKeys = true
+    }
+
+    inline fun <reified T> Marshal(v: T): Pair<MutableList<UByte>, GoError?> = bytes { text(v, "", null) }
+
+    inline fun <reified T> MarshalIndent(v: T, prefix: String, indent: String): Pair<MutableList<UByte>, GoError?> =
+        bytes { text(v, prefix, indent) }
+
+    // json.Unmarshal(data, &v) vira json.Unmarshal<T>(data) { v = it }
+    inline fun <reified T> Unmarshal(data: MutableList<UByte>, set: (T) -> Unit): GoError? =
+        try {
+            set(format.decodeFromString<T>(String(data.map { it.toByte() }.toByteArray())))
+            null
+        } catch (e: IllegalArgumentException) {
+            errors.New(e.message ?: "invalid JSON")
+        }
+
+    // Destinos com interface{} (map[string]interface{}, []any) viram
+    // json.UnmarshalAny(data) { v = it as T }, com os valores como no Go
+    fun UnmarshalAny(data: MutableList<UByte>, set: (Any?) -> Unit): GoError? =
+        try {
+            set(value(format.parseToJsonElement(String(data.map { it.toByte() }.toByteArray()))))
+            null
+        } catch (e: IllegalArgumentException) {
+            errors.New(e.message ?: "invalid JSON")
+        }
+
+    // Objetos viram map[string]interface{}, arrays []interface{} e números float64
+    fun value(element: JsonElement): Any? = when (element) {
+        is JsonNull -> null
+        is JsonPrimitive -> if (element.isString) element.content else element.booleanOrNull ?: element.double
+        is JsonObject -> element.mapValues { value(it.value) }.toMutableMap()
+        is JsonArray -> element.map { value(it) }.toMutableList()
+    }
+
+    @OptIn(ExperimentalSerializationApi::class)
+    @PublishedApi
+    internal inline fun <reified T> text(v: T, prefix: String, indent: String?): String {
+        if (indent == null) {
+            return format.encodeToString(v)
+        }
+        val pretty = Json(format) {
+            prettyPrint = true
+            prettyPrintIndent = indent
+        }
+        return pretty.encodeToString(v).replace("\n", "\n" + prefix)
+    }
+
+    @PublishedApi
+    internal fun bytes(encode: () -> String): Pair<MutableList<UByte>, GoError?> =
+        try {
+            Pair(encode().toByteArray().map { it.toUByte() }.toMutableList(), null)
+        } catch (e: IllegalArgumentException) {
+            Pair(mutableListOf(), errors.New(e.message ?: "invalid value"))
+        }
+}
+
+// Serializers da opção ,string das tags json: o número ou booleano vai entre aspas
+sealed class GoJsonQuoted<T>(name: String, private val parse: (String) -> T) : KSerializer<T> {
+    override val descriptor = PrimitiveSerialDescriptor("go2kt.$name", PrimitiveKind.STRING)
+
+    override fun serialize(encoder: Encoder, value: T) = encoder.encodeString(text(value))
+
+    override fun deserialize(decoder: Decoder): T = parse(decoder.decodeString())
+
+    open fun text(value: T): String = value.toString()
+
+    object IntValue : GoJsonQuoted<Int>("IntValue", String::toInt)
+
+    object LongValue : GoJsonQuoted<Long>("LongValue", String::toLong)
+
+    object BooleanValue : GoJsonQuoted<Boolean>("BooleanValue", String::toBooleanStrict)
+
+    // O Go escreve floats inteiros sem casas decimais (5, e não 5.0)
+    object DoubleValue : GoJsonQuoted<Double>("DoubleValue", String::toDouble) {
+        override fun text(value: Double): String =
+            if (value % 1.0 == 0.0 && Math.abs(value) < 1e21) value.toLong().toString() else value.toString()
+    }
+}
+
+// json.NewEncoder: escreve cada valor seguido de \n, como o Encoder do Go
+class GoJsonEncoder(@PublishedApi internal val out: Appendable) {
+    @PublishedApi
+    internal var prefix = ""
+
+    @PublishedApi
+    internal var indent: String? = null
+
+    fun SetIndent(prefix: String, indent: String) {
+        this.prefix = prefix
+        this.indent = indent
+    }
+
+    inline fun <reified T> Encode(v: T): GoError? =
+        try {
+            out.append(json.text(v, prefix, indent)).append('\n')
+            (out as? java.io.PrintStream)?.flush()
+            null
+        } catch (e: IllegalArgumentException) {
+            errors.New(e.message ?: "invalid value")
+        }
+}
+
+// json.NewDecoder: lê um valor JSON por vez; no fim da entrada Decode devolve io.EOF
+class GoJsonDecoder(input: java.io.InputStream) {
+    @OptIn(ExperimentalSerializationApi::class)
+    @PublishedApi
+    internal val values = json.format.decodeToSequence<JsonElement>(input, DecodeSequenceMode.WHITESPACE_SEPARATED).iterator()
+
+    // dec.Decode(&v) vira dec.Decode<T> { v = it }
+    inline fun <reified T> Decode(set: (T) -> Unit): GoError? =
+        try {
+            if (!values.hasNext()) {
+                io.EOF
+            } else {
+                set(json.format.decodeFromJsonElement<T>(values.next()))
+                null
+            }
+        } catch (e: IllegalArgumentException) {
+            errors.New(e.message ?: "invalid JSON")
+        }
+
+    fun DecodeAny(set: (Any?) -> Unit): GoError? =
+        try {
+            if (!values.hasNext()) {
+                io.EOF
+            } else {
+                set(json.value(values.next()))
+                null
+            }
+        } catch (e: IllegalArgumentException) {
+            errors.New(e.message ?: "invalid JSON")
+        }
+}
@@ -0,0 +1,8 @@
+// go2kt-runtime 1.1.0: funções do pacote math sem equivalente direto
+package go2kt.runtime
+
+// math.Round: arredonda metades para longe do zero (o round do Kotlin arredonda para o par)
+fun goRound(x: Double): Double {
+    val whole = if (x < 0) Math.ceil(x) else Math.floor(x)
+    return if (Math.abs(x - whole) >= 0.5) whole + Math.signum(x) else whole
+}
@@ -0,0 +1,35 @@
+// go2kt-runtime 1.1.0: pacote os (argumentos e arquivos)
+package go2kt.runtime
+
+// Erro de uma operação com arquivo, como o *fs.PathError do Go
+class PathError(val Op: String, val Path: String, val Err: GoError) : GoError() {
+    override fun Error(): String = "$Op $Path: ${Err.Error()}"
+    override fun Unwrap(): GoError? = Err
+}
+
+@Suppress("ClassName", "FunctionName")
+object os {
+    // Preenchido no início do main: o nome do programa seguido dos argumentos
+    var Args: MutableList<String> = mutableListOf("main")
+
+    val ErrNotExist: GoError = errors.New("file does not exist")
+
+    fun ReadFile(name: String): Pair<MutableList<UByte>, GoError?> =
+        try {
+            Pair(java.io.File(name).readBytes().map { it.toUByte() }.toMutableList(), null)
+        } catch (e: java.io.IOException) {
+            Pair(mutableListOf(), pathError("open", name, e))
+        }
+
+    // As permissões do Go (perm) não têm equivalente portável na JVM e são ignoradas
+    fun WriteFile(name: String, data: MutableList<UByte>, perm: UInt): GoError? =
+        try {
+            java.io.File(name).writeBytes(data.map { it.toByte() }.toByteArray())
+            null
+        } catch (e: java.io.IOException) {
+            pathError("open", name, e)
+        }
+
+    private fun pathError(op: String, path: String, e: java.io.IOException): GoError =
+        PathError(op, path, if (e is java.io.FileNotFoundException && !java.io.File(path).exists()) ErrNotExist else errors.New(e.message ?: "I/O error"))
+}
@@ -0,0 +1,6 @@
+// go2kt-runtime 1.1.0: panic do Go como exceção
+package go2kt.runtime
+
+class GoPanic(val value: Any?) : RuntimeException(if (value is Throwable) value.message else value.toString())
+
+fun panic(value: Any?): Nothing = throw GoPanic(value)
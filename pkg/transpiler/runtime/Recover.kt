@@ -0,0 +1,31 @@
+// go2kt-runtime 1.1.0: recover e execução dos defers durante um pânico
+package go2kt.runtime
+
+// Pânico em andamento enquanto as funções adiadas executam
+private val goPanicking = ThreadLocal<Throwable?>()
+
+// recover() devolve o valor do pânico em andamento e o interrompe; fora de um pânico, null
+fun recover(): Any? {
+    val err = goPanicking.get() ?: return null
+    goPanicking.set(null)
+    return if (err is GoPanic) err.value else err
+}
+
+// Executa as funções adiadas em ordem inversa. Durante um pânico (err não
+// nulo) elas podem interrompê-lo com recover(); se nenhuma o fizer, ele é relançado
+fun runDefers(defers: List<() -> Unit>, err: Throwable?) {
+    val outer = goPanicking.get()
+    goPanicking.set(err)
+    try {
+        for (f in defers.asReversed()) {
+            try {
+                f()
+            } catch (e: Throwable) {
+                goPanicking.set(e)
+            }
+        }
+        goPanicking.get()?.let { throw it }
+    } finally {
+        goPanicking.set(outer)
+    }
+}
@@ -0,0 +1,4 @@
+// go2kt-runtime 1.1.0: caixas para variáveis com endereço tomado (&x)
+package go2kt.runtime
+
+class Ref<T>(var value: T)
@@ -0,0 +1,10 @@
+// go2kt-runtime 1.1.0: sort.Slice com função less sobre índices
+package go2kt.runtime
+
+// Ordena a lista com a função less(i, j) do Go, que compara os elementos
+// pelos índices: os índices são ordenados antes de os elementos mudarem de lugar
+fun <T> sortSlice(list: MutableList<T>, less: (Int, Int) -> Boolean) {
+    val order = list.indices.sortedWith { i, j -> if (less(i, j)) -1 else if (less(j, i)) 1 else 0 }
+    val sorted = order.map { list[it] }
+    sorted.forEachIndexed { k, v -> list[k] = v }
+}
@@ -0,0 +1,106 @@
+// go2kt-runtime 1.1.0: funções do pacote strconv
+package go2kt.runtime
+
+// Erro das conversões do strconv, com a mesma mensagem do Go
+class NumError(val Func: String, val Num: String, val Err: GoError) : GoError() {
+    override fun Error(): String = "strconv." + Func + ": parsing " + strconv.Quote(Num) + ": " + Err.Error()
+    override fun Unwrap(): GoError = Err
+}
+
+@Suppress("ClassName", "FunctionName")
+object strconv {
+    val ErrSyntax = errors.New("invalid syntax")
+    val ErrRange = errors.New("value out of range")
+
+    fun Atoi(s: String): Pair<Int, GoError?> {
+        val (v, err) = ParseInt(s, 10, 32)
+        return Pair(v.toInt(), if (err == null) null else NumError("Atoi", s, (err as NumError).Err))
+    }
+
+    fun ParseInt(s: String, base: Int, bitSize: Int): Pair<Long, GoError?> {
+        val bits = if (bitSize == 0) 64 else bitSize
+        val negative = s.startsWith("-")
+        var digits = if (negative || s.startsWith("+")) s.substring(1) else s
+        var radix = base
+        if (base == 0) {
+            radix = 10
+            val lower = digits.lowercase()
+            when {
+                lower.startsWith("0x") -> { radix = 16; digits = digits.substring(2) }
+                lower.startsWith("0b") -> { radix = 2; digits = digits.substring(2) }
+                lower.startsWith("0o") -> { radix = 8; digits = digits.substring(2) }
+                lower.startsWith("0") && lower.length > 1 -> { radix = 8; digits = digits.substring(1) }
+            }
+            digits = digits.replace("_", "")
+        }
+        val magnitude = if (digits.isEmpty() || !digits.all { Character.digit(it, radix) >= 0 }) null else digits.toBigInteger(radix)
+        if (magnitude == null) {
+            return Pair(0L, NumError("ParseInt", s, ErrSyntax))
+        }
+        val value = if (negative) magnitude.negate() else magnitude
+        val max = java.math.BigInteger.ONE.shiftLeft(bits - 1)
+        return when {
+            value >= max -> Pair(max.toLong() - 1, NumError("ParseInt", s, ErrRange))
+            value < max.negate() -> Pair(max.negate().toLong(), NumError("ParseInt", s, ErrRange))
+            else -> Pair(value.toLong(), null)
+        }
+    }
+
+    fun ParseFloat(s: String, bitSize: Int): Pair<Double, GoError?> {
+        val text = s.replace("_", "")
+        val special = when (text.lowercase().removePrefix("+")) {
+            "inf", "infinity" -> Double.POSITIVE_INFINITY
+            "-inf", "-infinity" -> Double.NEGATIVE_INFINITY
+            "nan" -> Double.NaN
+            else -> null
+        }
+        if (special != null) {
+            return Pair(special, null)
+        }
+        // O Java aceita sufixos como 1.5f e 2d, que o Go rejeita
+        val value = if (text.isEmpty() || text.last().isLetter() && !text.lowercase().startsWith("0x")) null else text.toDoubleOrNull()
+        if (value == null) {
+            return Pair(0.0, NumError("ParseFloat", s, ErrSyntax))
+        }
+        val result = if (bitSize == 32) value.toFloat().toDouble() else value
+        if (result.isInfinite()) {
+            return Pair(result, NumError("ParseFloat", s, ErrRange))
+        }
+        return Pair(result, null)
+    }
+
+    fun ParseBool(s: String): Pair<Boolean, GoError?> = when (s) {
+        "1", "t", "T", "true", "TRUE", "True" -> Pair(true, null)
+        "0", "f", "F", "false", "FALSE", "False" -> Pair(false, null)
+        else -> Pair(false, NumError("ParseBool", s, ErrSyntax))
+    }
+
+    fun FormatFloat(f: Double, fmt: Char, prec: Int, bitSize: Int): String {
+        val value = if (bitSize == 32) f.toFloat().toDouble() else f
+        return when {
+            value.isNaN() -> "NaN"
+            value.isInfinite() -> if (value > 0) "+Inf" else "-Inf"
+            prec < 0 && fmt == 'f' -> java.math.BigDecimal(value.toString()).stripTrailingZeros().toPlainString()
+            prec < 0 -> value.toString()
+            else -> String.format(java.util.Locale.ROOT, "%." + prec + fmt, value)
+        }
+    }
+
+    fun Quote(s: String): String {
+        val out = StringBuilder().append('"')
+        var i = 0
+        while (i < s.length) {
+            val cp = s.codePointAt(i)
+            i += Character.charCount(cp)
+            when {
+                cp == '"'.code || cp == '\\'.code -> out.append('\\').appendCodePoint(cp)
+                cp == '\n'.code -> out.append("\\n")
+                cp == '\t'.code -> out.append("\\t")
+                cp == '\r'.code -> out.append("\\r")
+                cp < 0x20 || cp == 0x7f -> out.append("\\x").append(cp.toString(16).padStart(2, '0'))
+                else -> out.appendCodePoint(cp)
+            }
+        }
+        return out.append('"').toString()
+    }
+}
@@ -0,0 +1,41 @@
+// go2kt-runtime 1.1.0: funções do pacote strings sem equivalente direto
+package go2kt.runtime
+
+// strings.Replace com limite: substitui as n primeiras ocorrências (todas se n < 0)
+fun goReplace(s: String, old: String, new: String, n: Int): String {
+    if (n < 0) {
+        return s.replace(old, new)
+    }
+    val out = StringBuilder()
+    var start = 0
+    var count = 0
+    while (count < n) {
+        if (old.isEmpty()) {
+            // Sem padrão, o Go insere new antes de cada caractere
+            if (count > 0) {
+                if (start >= s.length) {
+                    break
+                }
+                val next = s.offsetByCodePoints(start, 1)
+                out.append(s, start, next)
+                start = next
+            }
+            out.append(new)
+        } else {
+            val i = s.indexOf(old, start)
+            if (i < 0) {
+                break
+            }
+            out.append(s, start, i).append(new)
+            start = i + old.length
+        }
+        count++
+    }
+    return out.append(s, start, s.length).toString()
+}
+
+// Com a opção Utf8Len, strings.Index e afins devolvem a posição em bytes UTF-8
+inline fun goByteIndex(s: String, find: (String) -> Int): Int {
+    val i = find(s)
+    return if (i < 0) i else s.substring(0, i).toByteArray().size
+}
@@ -0,0 +1,52 @@
+// go2kt-runtime 1.1.0: temporizadores e tickers do pacote time como canais alimentados por corrotinas
+package go2kt.runtime
+
+import java.time.Instant
+import kotlin.time.Duration
+import kotlinx.coroutines.launch
+
+// Os temporizadores não pertencem a quem os cria, como no runtime do Go
+private val goTimerScope = kotlinx.coroutines.CoroutineScope(kotlinx.coroutines.Dispatchers.Default)
+
+// time.NewTimer: C recebe o instante uma única vez, depois de d
+class GoTimer(d: Duration) {
+    val C = kotlinx.coroutines.channels.Channel<Instant>(1)
+    private var job = start(d)
+
+    private fun start(d: Duration) = goTimerScope.launch {
+        kotlinx.coroutines.delay(d)
+        C.trySend(Instant.now())
+    }
+
+    // Devolve true se o temporizador ainda não tinha disparado
+    fun Stop(): Boolean {
+        val active = job.isActive
+        job.cancel()
+        return active
+    }
+
+    fun Reset(d: Duration): Boolean {
+        val active = Stop()
+        job = start(d)
+        return active
+    }
+}
+
+// time.NewTicker: C recebe o instante a cada d; ticks não lidos são descartados, como no Go
+class GoTicker(d: Duration) {
+    val C = kotlinx.coroutines.channels.Channel<Instant>(1)
+    private val job = goTimerScope.launch {
+        while (true) {
+            kotlinx.coroutines.delay(d)
+            C.trySend(Instant.now())
+        }
+    }
+
+    fun Stop() {
+        job.cancel()
+    }
+}
+
+fun goAfter(d: Duration): kotlinx.coroutines.channels.Channel<Instant> = GoTimer(d).C
+
+fun goTick(d: Duration): kotlinx.coroutines.channels.Channel<Instant> = GoTicker(d).C
@@ -0,0 +1,4 @@
+// go2kt-runtime 1.1.0: versão
+package go2kt.runtime
+
+const val GO2KT_RUNTIME_VERSION = "1.1.0"
@@ -0,0 +1,256 @@
+package transpiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// sortFuncs traduz as funções dos pacotes sort, slices e maps para operações
+// das coleções do Kotlin (mesma notação de stringsFuncs)
+var sortFuncs = map[string]map[string]string{
+	"sort": {
+		"Ints":     "%0.sort()",
+		"Strings":  "%0.sort()",
+		"Float64s": "%0.sort()",
+	},
+	"slices": {
+		"Sort":           "%0.sort()",
+		"SortFunc":       "%0.sortWith(Comparator(%1))",
+		"SortStableFunc": "%0.sortWith(Comparator(%1))",
+		"Sorted":         "%0.sorted().toMutableList()",
+		"Contains":       "%0.contains(%1)",
+		"ContainsFunc":   "%0.any(%1)",
+		"Index":          "%0.indexOf(%1)",
+		"IndexFunc":      "%0.indexOfFirst(%1)",
+		"Max":            "%0.max()",
+		"Min":            "%0.min()",
+		"Reverse":        "%0.reverse()",
+		"Clone":          "%0.toMutableList()",
+		"Collect":        "%0.toMutableList()",
+		"Equal":          "(%0 == %1)",
+		"All":            "%0.withIndex()",
+		"Values":         "%0",
+	},
+	// Os iteradores (iter.Seq e iter.Seq2) viram Iterables do Kotlin
+	"maps": {
+		"Keys":   "%0.keys",
+		"Values": "%0.values",
+		"All":    "%0",
+		"Clone":  "%0.toMutableMap()",
+		"Copy":   "%0.putAll(%1)",
+		"Equal":  "(%0 == %1)",
+	},
+}
+
+// sortKey indica que s[i] é escrito como it ao traduzir a chave de um sort.Slice
+type sortKey struct {
+	slice types.Object
+	index types.Object
+}
+
+// transpileSort traduz as chamadas aos pacotes sort, slices e maps
+func (t *Transpiler) transpileSort(call *ast.CallExpr) bool {
+	for _, pkg := range []string{"sort", "slices", "maps"} {
+		name := t.pkgMember(call.Fun, pkg)
+		if name == "" {
+			continue
+		}
+		if pkg == "sort" && (name == "Slice" || name == "SliceStable") && len(call.Args) == 2 {
+			t.transpileSortSlice(call.Args[0], call.Args[1])
+			return true
+		}
+		if tmpl, ok := sortFuncs[pkg][name]; ok {
+			t.writeCallTemplate(tmpl, nil, call.Args)
+			return true
+		}
+		t.diagnose(call.Pos(), pkg+"."+name+" sem tradução para Kotlin; chamada mantida")
+		return false
+	}
+	return false
+}
+
+// transpileSortSlice traduz sort.Slice(s, less). O caso comum, em que less
+// compara a mesma chave de s[i] e s[j] com < ou >, vira sortBy/sortByDescending;
+// os demais usam sortSlice do runtime, que ordena os índices com a função less.
+func (t *Transpiler) transpileSortSlice(slice, less ast.Expr) {
+	if key, desc, ok := t.sortSliceKey(slice, less); ok {
+		t.transpileOperand(slice)
+		t.writeNonNull(slice)
+		if desc {
+			t.write(".sortByDescending { ")
+		} else {
+			t.write(".sortBy { ")
+		}
+		t.Transpile(key)
+		t.sortKey = nil
+		t.write(" }")
+		return
+	}
+	t.useRuntime("sortSlice")
+	t.write("sortSlice(")
+	t.Transpile(slice)
+	t.write(", ")
+	t.Transpile(less)
+	t.write(")")
+}
+
+// sortSliceKey reconhece less no formato func(i, j int) bool { return key(s[i]) < key(s[j]) }
+// e devolve key(s[i]), já registrando em t.sortKey a troca de s[i] por it
+func (t *Transpiler) sortSliceKey(slice, less ast.Expr) (ast.Expr, bool, bool) {
+	lit, ok := less.(*ast.FuncLit)
+	sliceID, isIdent := ast.Unparen(slice).(*ast.Ident)
+	if !ok || !isIdent || t.info == nil || len(lit.Body.List) != 1 {
+		return nil, false, false
+	}
+	ret, ok := lit.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return nil, false, false
+	}
+	cmp, ok := ast.Unparen(ret.Results[0]).(*ast.BinaryExpr)
+	if !ok || (cmp.Op != token.LSS && cmp.Op != token.GTR) {
+		return nil, false, false
+	}
+	var params []types.Object
+	for _, field := range lit.Type.Params.List {
+		for _, name := range field.Names {
+			params = append(params, t.objectOf(name))
+		}
+	}
+	s := t.objectOf(sliceID)
+	if len(params) != 2 || s == nil {
+		return nil, false, false
+	}
+	i, j := params[0], params[1]
+	desc := cmp.Op == token.GTR
+	left, right := cmp.X, cmp.Y
+	if t.keyOf(left, j, i, s) && t.keyOf(right, i, j, s) {
+		// s[j] < s[i] ordena de forma decrescente
+		left, right = right, left
+		desc = !desc
+	} else if !t.keyOf(left, i, j, s) || !t.keyOf(right, j, i, s) {
+		return nil, false, false
+	}
+	if t.keyShape(left, i, j) != t.keyShape(right, i, j) {
+		return nil, false, false
+	}
+	t.sortKey = &sortKey{slice: s, index: i}
+	return left, desc, true
+}
+
+// isSortKeyElem informa se n é o s[i] da chave de sort.Slice em tradução
+func (t *Transpiler) isSortKeyElem(n *ast.IndexExpr) bool {
+	if t.sortKey == nil {
+		return false
+	}
+	x, ok := ast.Unparen(n.X).(*ast.Ident)
+	idx, isIdent := ast.Unparen(n.Index).(*ast.Ident)
+	return ok && isIdent && t.objectOf(x) == t.sortKey.slice && t.objectOf(idx) == t.sortKey.index
+}
+
+// keyOf informa se expr usa o índice idx apenas como s[idx] e não usa other
+func (t *Transpiler) keyOf(expr ast.Expr, idx, other, s types.Object) bool {
+	uses, ok := 0, true
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.IndexExpr:
+			if x, isIdent := ast.Unparen(n.X).(*ast.Ident); isIdent && t.objectOf(x) == s {
+				if id, isIdent := ast.Unparen(n.Index).(*ast.Ident); isIdent && t.objectOf(id) == idx {
+					uses++
+					return false
+				}
+			}
+		case *ast.Ident:
+			if obj := t.objectOf(n); obj == idx || obj == other {
+				ok = false
+			}
+		case *ast.FuncLit:
+			ok = false
+		}
+		return ok
+	})
+	return ok && uses > 0
+}
+
+// keyShape descreve a estrutura de expr com os índices i e j trocados por #,
+// para comparar os dois lados da comparação de um sort.Slice
+func (t *Transpiler) keyShape(expr ast.Expr, i, j types.Object) string {
+	var b strings.Builder
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case nil:
+			b.WriteString(")")
+			return false
+		case *ast.Ident:
+			if obj := t.objectOf(n); obj == i || obj == j {
+				b.WriteString("#")
+			} else {
+				b.WriteString(n.Name)
+			}
+		case *ast.BasicLit:
+			b.WriteString(n.Value)
+		case *ast.BinaryExpr:
+			b.WriteString(n.Op.String())
+		case *ast.UnaryExpr:
+			b.WriteString(n.Op.String())
+		}
+		fmt.Fprintf(&b, "(%T", n)
+		return true
+	})
+	return b.String()
+}
+
+// transpileRangeIterable traduz o range sobre maps e sobre os iteradores do
+// maps e do slices, que viram Iterables: for ((k, v) in m) e for (k in m.keys)
+func (t *Transpiler) transpileRangeIterable(n *ast.RangeStmt, key, val string) bool {
+	typ := t.typeOf(n.X)
+	if typ == nil {
+		return false
+	}
+	pair := false
+	switch u := typ.Underlying().(type) {
+	case *types.Map:
+		pair = val != ""
+	case *types.Signature:
+		// iter.Seq2 chama yield com dois valores
+		yield, ok := u.Params().At(0).Type().Underlying().(*types.Signature)
+		pair = ok && yield.Params().Len() == 2
+		if !t.isIterable(n.X) {
+			t.diagnose(n.X.Pos(), "range sobre função iteradora sem tradução para Kotlin")
+		}
+	default:
+		return false
+	}
+	if pair {
+		if val == "" {
+			val = "_"
+		}
+		t.write("(" + key + ", " + val + ") in ")
+	} else {
+		t.write(key + " in ")
+	}
+	t.Transpile(n.X)
+	t.writeNonNull(n.X)
+	if _, isMap := typ.Underlying().(*types.Map); isMap && !pair {
+		t.write(".keys")
+	}
+	t.write(") ")
+	t.Transpile(n.Body)
+	return true
+}
+
+// isIterable informa se o iterador é uma chamada traduzida do maps ou do slices
+func (t *Transpiler) isIterable(expr ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	for _, pkg := range []string{"maps", "slices"} {
+		if _, ok := sortFuncs[pkg][t.pkgMember(call.Fun, pkg)]; ok {
+			return true
+		}
+	}
+	return false
+}
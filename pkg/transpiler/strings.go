@@ -0,0 +1,297 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// stringsFuncs traduz funções do pacote strings para métodos de String do
+// Kotlin: %0 é a string recebida e %1, %2... os demais argumentos. Os índices
+// (Index, LastIndex) contam unidades UTF-16, que só coincidem com os bytes do
+// Go em ASCII; com a opção Utf8Len eles passam por stringsByteIndex.
+var stringsFuncs = map[string]string{
+	"Contains":     "%0.contains(%1)",
+	"ContainsRune": "%0.contains(%1)",
+	"ContainsAny":  "%0.any { it in %1 }",
+	"HasPrefix":    "%0.startsWith(%1)",
+	"HasSuffix":    "%0.endsWith(%1)",
+	"Index":        "%0.indexOf(%1)",
+	"IndexByte":    "%0.indexOf(%1.toInt().toChar())",
+	"IndexRune":    "%0.indexOf(%1)",
+	"LastIndex":    "%0.lastIndexOf(%1)",
+	"Count":        "(%0.split(%1).size - 1)",
+	"EqualFold":    "%0.equals(%1, ignoreCase = true)",
+	"Fields":       `%0.split(Regex("\\s+")).filter { it.isNotEmpty() }.toMutableList()`,
+	"Join":         "%0.joinToString(%1)",
+	"Repeat":       "%0.repeat(%1)",
+	"ReplaceAll":   "%0.replace(%1, %2)",
+	"ToUpper":      "%0.uppercase()",
+	"ToLower":      "%0.lowercase()",
+	"TrimSpace":    "%0.trim()",
+	"Trim":         "%0.trim { it in %1 }",
+	"TrimLeft":     "%0.trimStart { it in %1 }",
+	"TrimRight":    "%0.trimEnd { it in %1 }",
+	"TrimPrefix":   "%0.removePrefix(%1)",
+	"TrimSuffix":   "%0.removeSuffix(%1)",
+	"NewReader":    "%0.byteInputStream()",
+}
+
+// stringsByteIndex traduz as buscas de strings com a opção Utf8Len: o índice
+// UTF-16 encontrado vira a posição em bytes UTF-8, como no Go
+var stringsByteIndex = map[string]string{
+	"Index":     "goByteIndex(%0) { it.indexOf(%1) }",
+	"IndexByte": "goByteIndex(%0) { it.indexOf(%1.toInt().toChar()) }",
+	"IndexRune": "goByteIndex(%0) { it.indexOf(%1) }",
+	"LastIndex": "goByteIndex(%0) { it.lastIndexOf(%1) }",
+}
+
+// builderMethods traduz os métodos de strings.Builder para StringBuilder (%r é o receptor)
+var builderMethods = map[string]string{
+	"WriteString": "%r.append(%0)",
+	"WriteRune":   "%r.append(%0)",
+	"WriteByte":   "%r.append(%0.toInt().toChar())",
+	"String":      "%r.toString()",
+	"Len":         "%r.length",
+	"Reset":       "%r.setLength(0)",
+	"Grow":        "%r.ensureCapacity(%0)",
+}
+
+// strconvFuncs traduz as conversões do strconv que não podem falhar; as que
+// devolvem (valor, error) usam o objeto strconv do runtime, com Pair<T, GoError?>
+var strconvFuncs = map[string]string{
+	"Itoa":       "%0.toString()",
+	"FormatInt":  "%0.toString(%1)",
+	"FormatBool": "%0.toString()",
+}
+
+// strconvRuntime são as funções do objeto strconv do runtime
+var strconvRuntime = map[string]bool{
+	"Atoi":        true,
+	"ParseInt":    true,
+	"ParseFloat":  true,
+	"ParseBool":   true,
+	"FormatFloat": true,
+	"Quote":       true,
+}
+
+// stdTypes associa tipos da biblioteca padrão do Go a classes do Kotlin;
+// nomes qualificados são importados
+var stdTypes = map[string]string{
+	"strings.Builder":       "StringBuilder",
+	"math/rand.Rand":        "kotlin.random.Random",
+	"bufio.Scanner":         "Sequence<String>",
+	"bufio.Reader":          "java.io.InputStream",
+	"strings.Reader":        "java.io.InputStream",
+	"bufio.Writer":          "java.io.BufferedWriter",
+	"time.Duration":         "kotlin.time.Duration",
+	"time.Time":             "java.time.Instant",
+	"time.Timer":            "GoTimer",
+	"time.Ticker":           "GoTicker",
+	"encoding/json.Encoder": "GoJsonEncoder",
+	"encoding/json.Decoder": "GoJsonDecoder",
+}
+
+// stdZeros são os valores zero das classes de stdTypes sem construtor vazio
+var stdZeros = map[string]string{
+	"Duration": "Duration.ZERO",
+	"Instant":  "Instant.MIN",
+}
+
+// stdType devolve a classe Kotlin de um tipo da biblioteca padrão (ex: StringBuilder)
+func (t *Transpiler) stdType(typ types.Type) (string, bool) {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return "", false
+	}
+	kt, ok := stdTypes[named.Obj().Pkg().Path()+"."+named.Obj().Name()]
+	if _, isRuntime := runtimeSymbols[kt]; isRuntime {
+		t.useRuntime(kt)
+	}
+	if i := strings.LastIndex(kt, "."); i >= 0 {
+		t.useImport(kt)
+		kt = kt[i+1:]
+	}
+	return kt, ok
+}
+
+// transpileStrings traduz chamadas aos pacotes strings e strconv e aos métodos
+// de strings.Builder. Funções sem tradução são mantidas com um aviso.
+func (t *Transpiler) transpileStrings(call *ast.CallExpr) bool {
+	if name := t.pkgMember(call.Fun, "strings"); name != "" {
+		switch {
+		case name == "Split":
+			return t.transpileSplit(call)
+		case name == "Replace":
+			return t.transpileReplace(call)
+		case t.options.Utf8Len && stringsByteIndex[name] != "":
+			t.useRuntime("goByteIndex")
+			t.writeCallTemplate(stringsByteIndex[name], nil, call.Args)
+			return true
+		case stringsFuncs[name] != "":
+			t.writeCallTemplate(stringsFuncs[name], nil, call.Args)
+			return true
+		}
+		t.diagnose(call.Pos(), "strings."+name+" sem tradução para Kotlin; chamada mantida")
+		return false
+	}
+	if name := t.pkgMember(call.Fun, "strconv"); name != "" {
+		switch {
+		case strconvFuncs[name] != "":
+			t.writeCallTemplate(strconvFuncs[name], nil, call.Args)
+			return true
+		case name == "FormatFloat" && len(call.Args) == 4:
+			// O verbo é um byte no Go e um Char no runtime
+			t.useRuntime("strconv")
+			t.write("strconv.FormatFloat(")
+			t.Transpile(call.Args[0])
+			t.write(", ")
+			if val, ok := t.constValue(call.Args[1]); ok {
+				r, _ := constant.Int64Val(constant.ToInt(val))
+				t.write(quoteKotlinChar(rune(r)))
+			} else {
+				t.transpileOperand(call.Args[1])
+				t.write(".toInt().toChar()")
+			}
+			for _, arg := range call.Args[2:] {
+				t.write(", ")
+				t.Transpile(arg)
+			}
+			t.write(")")
+			return true
+		case strconvRuntime[name]:
+			t.useRuntime("strconv")
+			t.write("strconv." + name)
+			t.writeCallArgs(call)
+			return true
+		}
+		t.diagnose(call.Pos(), "strconv."+name+" sem tradução para Kotlin; chamada mantida")
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	recv := t.typeOf(sel.X)
+	if recv == nil {
+		return false
+	}
+	if ptr, ok := recv.Underlying().(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	if kt, ok := t.stdType(recv); !ok || kt != "StringBuilder" || builderMethods[sel.Sel.Name] == "" {
+		return false
+	}
+	if sel.Sel.Name == "WriteByte" && len(call.Args) == 1 {
+		// Bytes constantes são escritos como caractere
+		if val, ok := t.constValue(call.Args[0]); ok {
+			r, _ := constant.Int64Val(constant.ToInt(val))
+			t.writeCallTemplate("%r.append("+quoteKotlinChar(rune(r))+")", sel.X, nil)
+			return true
+		}
+	}
+	t.writeCallTemplate(builderMethods[sel.Sel.Name], sel.X, call.Args)
+	return true
+}
+
+// transpileSplit traduz strings.Split; o separador vazio separa cada caractere
+func (t *Transpiler) transpileSplit(call *ast.CallExpr) bool {
+	if len(call.Args) != 2 {
+		return false
+	}
+	if val, ok := t.constValue(call.Args[1]); ok && val.Kind() == constant.String && constant.StringVal(val) == "" {
+		t.writeCallTemplate("%0.map { it.toString() }.toMutableList()", nil, call.Args)
+		return true
+	}
+	t.writeCallTemplate("%0.split(%1).toMutableList()", nil, call.Args)
+	return true
+}
+
+// transpileReplace traduz strings.Replace: n = -1 substitui todas as
+// ocorrências e n = 1 apenas a primeira; os demais limites usam o runtime
+func (t *Transpiler) transpileReplace(call *ast.CallExpr) bool {
+	if len(call.Args) != 4 {
+		return false
+	}
+	if val, ok := t.constValue(call.Args[3]); ok {
+		switch n, _ := constant.Int64Val(constant.ToInt(val)); n {
+		case -1:
+			t.writeCallTemplate("%0.replace(%1, %2)", nil, call.Args)
+			return true
+		case 1:
+			t.writeCallTemplate("%0.replaceFirst(%1, %2)", nil, call.Args)
+			return true
+		}
+	}
+	t.useRuntime("goReplace")
+	t.writeCallTemplate("goReplace(%0, %1, %2, %3)", nil, call.Args)
+	return true
+}
+
+// writeCallTemplate escreve um modelo de tradução trocando %r pelo receptor e
+// %0, %1... pelos argumentos. Receptores de chamadas entram entre parênteses.
+func (t *Transpiler) writeCallTemplate(tmpl string, recv ast.Expr, args []ast.Expr) {
+	for i := 0; i < len(tmpl); i++ {
+		if tmpl[i] != '%' || i+1 == len(tmpl) {
+			t.write(tmpl[i : i+1])
+			continue
+		}
+		var arg ast.Expr
+		if tmpl[i+1] == 'r' {
+			arg = recv
+		} else if n, err := strconv.Atoi(tmpl[i+1 : i+2]); err == nil && n < len(args) {
+			arg = args[n]
+		}
+		if arg == nil {
+			t.write(tmpl[i : i+1])
+			continue
+		}
+		i++
+		if i+1 < len(tmpl) && tmpl[i+1] == '.' {
+			t.transpileOperand(arg)
+			t.writeNonNull(arg)
+		} else {
+			t.Transpile(arg)
+		}
+	}
+}
+
+// constValue devolve o valor de uma expressão constante
+func (t *Transpiler) constValue(expr ast.Expr) (constant.Value, bool) {
+	if t.info == nil {
+		return nil, false
+	}
+	tv, ok := t.info.Types[expr]
+	if !ok || tv.Value == nil {
+		return nil, false
+	}
+	return tv.Value, true
+}
+
+// transpileStringByte traduz s[i], que no Go é o byte i da string: com a
+// opção Utf8Len lê os bytes UTF-8, senão o código do caractere como UByte
+func (t *Transpiler) transpileStringByte(n *ast.IndexExpr) bool {
+	typ := t.typeOf(n.X)
+	if typ == nil {
+		return false
+	}
+	if basic, ok := typ.Underlying().(*types.Basic); !ok || basic.Info()&types.IsString == 0 {
+		return false
+	}
+	t.transpileOperand(n.X)
+	if t.valueClassOf(typ) != nil {
+		t.write(".value")
+	}
+	if t.options.Utf8Len {
+		t.write(".encodeToByteArray()[")
+		t.writeIntOperand(n.Index, "Int", false)
+		t.write("].toUByte()")
+		return true
+	}
+	t.write("[")
+	t.writeIntOperand(n.Index, "Int", false)
+	t.write("].code.toUByte()")
+	return true
+}
@@ -0,0 +1,477 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// classInfo guarda o que uma struct (ou value class) precisa declarar para
+// satisfazer as interfaces do pacote no Kotlin
+type classInfo struct {
+	supertypes []string
+	overrides  map[string]bool
+	forwarders []forwarder
+}
+
+// forwarder é um método promovido de um campo embutido que precisa ser
+// repassado explicitamente para satisfazer uma interface
+type forwarder struct {
+	method *types.Func
+	path   string
+}
+
+// collectInterfaces identifica as interfaces do pacote que viram interfaces
+// Kotlin e calcula, para cada tipo, as interfaces implementadas e os métodos
+// promovidos (inclusive dos campos de interface embutidos) a repassar.
+func (t *Transpiler) collectInterfaces(file *ast.File) {
+	if t.info == nil {
+		return
+	}
+	var ifaces []*types.Named
+	var named []*types.Named
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			obj, ok := t.objectOf(ts.Name).(*types.TypeName)
+			if !ok || ts.Assign.IsValid() {
+				continue
+			}
+			nt, ok := obj.Type().(*types.Named)
+			if !ok || nt.TypeParams().Len() > 0 {
+				continue
+			}
+			if iface, ok := nt.Underlying().(*types.Interface); ok {
+				if iface.NumMethods() > 0 && ts.TypeParams == nil {
+					t.interfaces[obj.Name()] = true
+					ifaces = append(ifaces, nt)
+				}
+				continue
+			}
+			named = append(named, nt)
+		}
+	}
+
+	for _, nt := range named {
+		name := nt.Obj().Name()
+		if t.enums[name] {
+			continue
+		}
+		info := &classInfo{overrides: make(map[string]bool)}
+
+		// Campos de interface embutidos são anuláveis (o zero do Go é nil), o que
+		// impede o by do Kotlin: os métodos são repassados com bar!!.M(), que
+		// falha como a chamada em uma interface nil no Go
+		var delegates []*types.Named
+		if st, ok := nt.Underlying().(*types.Struct); ok {
+			for i := 0; i < st.NumFields(); i++ {
+				f := st.Field(i)
+				if !f.Embedded() {
+					continue
+				}
+				if en, ok := f.Type().(*types.Named); ok && t.interfaces[en.Obj().Name()] && en.Obj().Pkg() == t.pkg {
+					info.supertypes = append(info.supertypes, t.objName(en.Obj()))
+					delegates = append(delegates, en)
+					t.addOverrides(info, en)
+					t.addForwarders(info, nt, en.Underlying().(*types.Interface))
+				}
+			}
+		}
+
+		for _, iface := range ifaces {
+			it := iface.Underlying().(*types.Interface)
+			if !types.Implements(nt, it) && !types.Implements(types.NewPointer(nt), it) {
+				continue
+			}
+			covered := false
+			for _, d := range delegates {
+				if embedsInterface(d, iface) {
+					covered = true
+					break
+				}
+			}
+			if covered {
+				continue
+			}
+			info.supertypes = append(info.supertypes, t.objName(iface.Obj()))
+			t.addOverrides(info, iface)
+			t.addForwarders(info, nt, it)
+		}
+
+		// Tipos com Error() string implementam error: herdam de GoError
+		if types.Implements(types.NewPointer(nt), errorInterface) {
+			t.useRuntime("GoError")
+			info.supertypes = append([]string{"GoError()"}, info.supertypes...)
+			for _, method := range []string{"Error", "Unwrap", "Is"} {
+				if t.overridesGoError(nt, method) {
+					info.overrides[method] = true
+				}
+			}
+			// Receiver por valor: o erro é comparado pelo conteúdo em errors.Is
+			if types.Implements(nt, errorInterface) {
+				t.useRuntime("GoValueError")
+				info.supertypes = append(info.supertypes, "GoValueError")
+			}
+		}
+
+		if len(info.supertypes) > 0 {
+			t.classes[name] = info
+			t.memberTypes[name] = true
+		}
+	}
+}
+
+// errorType e errorInterface são a interface error do Go
+var (
+	errorType      = types.Universe.Lookup("error").Type()
+	errorInterface = errorType.Underlying().(*types.Interface)
+)
+
+// overridesGoError informa se o tipo declara o método com a assinatura do
+// GoError: Error() string, Unwrap() error ou Is(error) bool
+func (t *Transpiler) overridesGoError(nt *types.Named, name string) bool {
+	obj, index, _ := types.LookupFieldOrMethod(types.NewPointer(nt), true, t.pkg, name)
+	fn, ok := obj.(*types.Func)
+	if !ok || len(index) != 1 {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	switch name {
+	case "Error":
+		return sig.Params().Len() == 0 && sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), types.Typ[types.String])
+	case "Unwrap":
+		return sig.Params().Len() == 0 && sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), errorType)
+	case "Is":
+		return sig.Params().Len() == 1 && types.Identical(sig.Params().At(0).Type(), errorType) &&
+			sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool])
+	}
+	return false
+}
+
+// addOverrides marca os métodos da interface como override na classe
+func (t *Transpiler) addOverrides(info *classInfo, iface *types.Named) {
+	methods := iface.Underlying().(*types.Interface)
+	for i := 0; i < methods.NumMethods(); i++ {
+		info.overrides[methods.Method(i).Name()] = true
+	}
+}
+
+// addForwarders registra os repasses dos métodos da interface que o tipo
+// só tem promovidos de campos embutidos
+func (t *Transpiler) addForwarders(info *classInfo, nt *types.Named, it *types.Interface) {
+	for i := 0; i < it.NumMethods(); i++ {
+		m := it.Method(i)
+		obj, index, _ := types.LookupFieldOrMethod(nt, true, t.pkg, m.Name())
+		fn, ok := obj.(*types.Func)
+		if !ok || len(index) < 2 || hasForwarder(info, m.Name()) {
+			continue
+		}
+		info.forwarders = append(info.forwarders, forwarder{method: fn, path: t.embedPath(nt, index)})
+	}
+}
+
+func hasForwarder(info *classInfo, name string) bool {
+	for _, f := range info.forwarders {
+		if f.method.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// embedsInterface informa se a interface Kotlin gerada para d herda de target
+func embedsInterface(d, target *types.Named) bool {
+	if d.Obj() == target.Obj() {
+		return true
+	}
+	iface, ok := d.Underlying().(*types.Interface)
+	if !ok {
+		return false
+	}
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		if en, ok := iface.EmbeddedType(i).(*types.Named); ok && embedsInterface(en, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// embedPath devolve o caminho de campos embutidos percorrido por um seletor
+// promovido (ex: ".Base.Inner"), sem incluir o membro final
+func (t *Transpiler) embedPath(recv types.Type, index []int) string {
+	path := ""
+	typ := recv
+	for _, idx := range index[:len(index)-1] {
+		if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		st, ok := typ.Underlying().(*types.Struct)
+		if !ok {
+			break
+		}
+		f := st.Field(idx)
+		path += "." + t.objName(f)
+		// Ponteiros e interfaces embutidos são anuláveis
+		if nullableZero(f.Type()) {
+			path += "!!"
+		}
+		typ = f.Type()
+	}
+	return path
+}
+
+// transpileSelection traduz um seletor usando as regras de promoção do Go,
+// injetando o caminho completo pelos campos embutidos
+func (t *Transpiler) transpileSelection(n *ast.SelectorExpr) bool {
+	if t.info == nil {
+		return false
+	}
+	if selection, ok := t.info.Selections[n]; ok {
+		t.Transpile(n.X)
+		if t.mayBeNull(n.X) || selection.Kind() == types.MethodVal && t.mayBeNilInterface(n.X) {
+			t.write("!!")
+		}
+		if selection.Kind() != types.MethodExpr {
+			t.write(t.embedPath(selection.Recv(), selection.Index()))
+		}
+		t.write("." + t.ident(n.Sel))
+		return true
+	}
+
+	// Seletor não resolvido: verifica se é ambíguo (mesmo nome em embeds de mesma profundidade)
+	tv, ok := t.info.Types[n.X]
+	if !ok || tv.Type == nil || tv.IsType() {
+		return false
+	}
+	obj, index, _ := types.LookupFieldOrMethod(tv.Type, true, t.pkg, n.Sel.Name)
+	if obj == nil && index != nil {
+		t.diagnose(n.Sel.Pos(), "seletor ambíguo: "+n.Sel.Name+" existe em mais de um campo embutido de "+t.resolveGoType(tv.Type))
+		t.Transpile(n.X)
+		t.write("./* ERRO: seletor ambíguo */" + n.Sel.Name)
+		return true
+	}
+	return false
+}
+
+// writeStruct gera a data class de uma struct, com o valor zero de cada campo
+// como valor padrão do construtor. Se a struct implementa interfaces do
+// pacote, os métodos são emitidos como membros.
+func (t *Transpiler) writeStruct(ts *ast.TypeSpec, st *ast.StructType) {
+	def := StructDef{Fields: make(map[string]bool), Embeds: []string{}}
+	var goStruct *types.Struct
+	if obj := t.objectOf(ts.Name); obj != nil {
+		goStruct, _ = obj.Type().Underlying().(*types.Struct)
+	}
+
+	serializable := t.serializable[ts.Name.Name]
+	if serializable {
+		t.writeSerializable(st)
+	}
+
+	// Data classes precisam de ao menos um parâmetro no construtor
+	if st.Fields == nil || len(st.Fields.List) == 0 {
+		t.write("class " + t.ident(ts.Name))
+		t.structs[ts.Name.Name] = def
+		if t.memberTypes[ts.Name.Name] {
+			t.writeClassBody(ts.Name.Name)
+		}
+		return
+	}
+
+	t.write("data class " + t.ident(ts.Name) + "(")
+	index := 0
+	writeField := func(field *ast.Field, name, typeStr string) {
+		if index > 0 {
+			t.write(", ")
+		}
+		decl := "var " + name + ": " + typeStr
+		var f *types.Var
+		if goStruct != nil && index < goStruct.NumFields() {
+			f = goStruct.Field(index)
+			keyword := t.fieldKeyword(f)
+			decl = keyword + " " + name + ": " + nullableType(typeStr, f.Type()) + " = " + t.zeroValue(f.Type())
+		}
+		if serializable {
+			decl = t.jsonAnnotations(field, f, name) + decl
+		}
+		t.write(decl)
+		index++
+	}
+	for _, field := range st.Fields.List {
+		typeStr := t.resolveType(field.Type)
+		if len(field.Names) == 0 {
+			fieldName := strings.TrimSuffix(typeStr, "?")
+			if idx := strings.LastIndex(fieldName, "."); idx != -1 {
+				fieldName = fieldName[idx+1:]
+			}
+			writeField(field, fieldName, typeStr)
+			def.Embeds = append(def.Embeds, fieldName)
+		} else {
+			for _, name := range field.Names {
+				writeField(field, t.ident(name), typeStr)
+				def.Fields[name.Name] = true
+			}
+		}
+	}
+	t.write(")")
+	t.structs[ts.Name.Name] = def
+
+	if t.memberTypes[ts.Name.Name] {
+		t.writeClassBody(ts.Name.Name)
+	}
+}
+
+// transpileStructLit traduz um literal de struct. Literais com chaves usam
+// argumentos nomeados; os campos omitidos ficam com o valor padrão (zero).
+func (t *Transpiler) transpileStructLit(n *ast.CompositeLit, typ types.Type) {
+	name := t.resolveGoType(typ)
+	t.write(name + "(")
+	for i, elt := range n.Elts {
+		if i > 0 {
+			t.write(", ")
+		}
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				t.write(t.ident(key) + " = ")
+				t.transpileCopied(kv.Value)
+				continue
+			}
+		}
+		t.transpileCopied(elt)
+	}
+	t.write(")")
+}
+
+// writeSupertypes escreve a lista de interfaces implementadas (": A, B")
+func (t *Transpiler) writeSupertypes(name string, extra ...string) {
+	supertypes := extra
+	if info := t.classes[name]; info != nil {
+		supertypes = append(supertypes, info.supertypes...)
+	}
+	if len(supertypes) > 0 {
+		t.write(" : " + strings.Join(supertypes, ", "))
+	}
+}
+
+// writeClassBody escreve os supertipos e o corpo da classe com os métodos
+// declarados para o tipo e os repasses de métodos promovidos
+func (t *Transpiler) writeClassBody(name string) {
+	t.writeSupertypes(name)
+	t.write(" {\n")
+	t.indent()
+	t.writeMembers(name)
+	t.unindent()
+	t.writeIndent()
+	t.write("}")
+}
+
+// writeMembers emite, dentro do corpo da classe, os métodos declarados no
+// arquivo para o tipo e os repasses de métodos promovidos
+func (t *Transpiler) writeMembers(name string) {
+	first := true
+	if info := t.classes[name]; info != nil {
+		for _, f := range info.forwarders {
+			t.writeForwarder(f)
+			first = false
+		}
+	}
+	if t.file == nil {
+		return
+	}
+	outer := t.memberOf
+	t.memberOf = name
+	for _, decl := range t.file.Decls {
+		if fd, ok := decl.(*ast.FuncDecl); ok && receiverTypeName(fd) == name {
+			if !first {
+				t.write("\n")
+			}
+			first = false
+			t.Transpile(fd)
+			t.write("\n")
+		}
+	}
+	t.memberOf = outer
+}
+
+// hasMembers informa se a classe terá métodos no corpo
+func (t *Transpiler) hasMembers(name string) bool {
+	if info := t.classes[name]; info != nil && len(info.forwarders) > 0 {
+		return true
+	}
+	if t.file != nil {
+		for _, decl := range t.file.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && receiverTypeName(fd) == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeForwarder gera o repasse de um método promovido: override fun M(a: T) = emb.M(a)
+func (t *Transpiler) writeForwarder(f forwarder) {
+	sig := f.method.Type().(*types.Signature)
+	var params, args []string
+	for i := 0; i < sig.Params().Len(); i++ {
+		p := sig.Params().At(i)
+		pname := t.objName(p)
+		if pname == "" || pname == "_" {
+			pname = "p" + strconv.Itoa(i)
+		}
+		params = append(params, pname+": "+t.resolveGoType(p.Type()))
+		args = append(args, pname)
+	}
+	t.writeIndent()
+	t.write("override fun " + t.objName(f.method) + "(" + strings.Join(params, ", ") + ")")
+	if sig.Results().Len() > 0 {
+		t.write(": " + t.resolveGoType(sig.Results().At(0).Type()))
+	}
+	t.write(" = " + strings.TrimPrefix(f.path, ".") + "." + t.objName(f.method) + "(" + strings.Join(args, ", ") + ")\n")
+}
+
+// writeInterface gera uma interface Kotlin com as assinaturas dos métodos;
+// interfaces embutidas viram supertipos
+func (t *Transpiler) writeInterface(ts *ast.TypeSpec, it *ast.InterfaceType) {
+	var supers []string
+	var methods []*ast.Field
+	for _, field := range it.Methods.List {
+		if len(field.Names) == 0 {
+			if id, ok := field.Type.(*ast.Ident); ok && t.interfaces[id.Name] {
+				supers = append(supers, t.ident(id))
+			}
+			continue
+		}
+		methods = append(methods, field)
+	}
+	sort.Strings(supers)
+
+	t.write("interface " + t.ident(ts.Name))
+	if len(supers) > 0 {
+		t.write(" : " + strings.Join(supers, ", "))
+	}
+	t.write(" {\n")
+	t.indent()
+	for _, m := range methods {
+		ft := m.Type.(*ast.FuncType)
+		for _, name := range m.Names {
+			t.writeIndent()
+			t.write("fun " + t.ident(name) + "(")
+			t.writeParams(ft.Params)
+			t.write(")")
+			if ft.Results != nil && len(ft.Results.List) > 0 {
+				t.write(": " + t.resolveType(ft.Results.List[0].Type))
+			}
+			t.write("\n")
+		}
+	}
+	t.unindent()
+	t.writeIndent()
+	t.write("}")
+}
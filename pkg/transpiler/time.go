@@ -0,0 +1,438 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// durationUnits são as unidades de kotlin.time.Duration, da maior para a
+// menor, com o tamanho em nanossegundos
+var durationUnits = []struct {
+	name string
+	ns   int64
+}{
+	{"hours", 3600e9},
+	{"minutes", 60e9},
+	{"seconds", 1e9},
+	{"milliseconds", 1e6},
+	{"microseconds", 1e3},
+	{"nanoseconds", 1},
+}
+
+// timeFuncs traduz as funções do pacote time (mesma notação de stringsFuncs)
+var timeFuncs = map[string]string{
+	"Sleep":     "delay(%0)",
+	"Since":     "java.time.Duration.between(%0, Instant.now()).toKotlinDuration()",
+	"Until":     "java.time.Duration.between(Instant.now(), %0).toKotlinDuration()",
+	"Unix":      "Instant.ofEpochSecond(%0, %1)",
+	"UnixMilli": "Instant.ofEpochMilli(%0)",
+	"After":     "goAfter(%0)",
+	"Tick":      "goTick(%0)",
+	"NewTimer":  "GoTimer(%0)",
+	"NewTicker": "GoTicker(%0)",
+}
+
+// durationMethods traduz os métodos de time.Duration (%r é o receptor)
+var durationMethods = map[string]string{
+	"Hours":        "%r.toDouble(DurationUnit.HOURS)",
+	"Minutes":      "%r.toDouble(DurationUnit.MINUTES)",
+	"Seconds":      "%r.toDouble(DurationUnit.SECONDS)",
+	"Milliseconds": "%r.inWholeMilliseconds",
+	"Microseconds": "%r.inWholeMicroseconds",
+	"Nanoseconds":  "%r.inWholeNanoseconds",
+	"Abs":          "%r.absoluteValue",
+	"String":       "%r.toString()",
+}
+
+// instantMethods traduz os métodos de time.Time para java.time.Instant; os
+// campos do calendário usam o fuso local, como time.Now() no Go
+var instantMethods = map[string]string{
+	"Sub":        "java.time.Duration.between(%0, %r).toKotlinDuration()",
+	"Add":        "%r.plus(%0.toJavaDuration())",
+	"Before":     "%r.isBefore(%0)",
+	"After":      "%r.isAfter(%0)",
+	"Equal":      "(%r == %0)",
+	"IsZero":     "(%r == Instant.MIN)",
+	"Unix":       "%r.epochSecond",
+	"UnixMilli":  "%r.toEpochMilli()",
+	"Year":       "%r.atZone(ZoneId.systemDefault()).year",
+	"YearDay":    "%r.atZone(ZoneId.systemDefault()).dayOfYear",
+	"Day":        "%r.atZone(ZoneId.systemDefault()).dayOfMonth",
+	"Hour":       "%r.atZone(ZoneId.systemDefault()).hour",
+	"Minute":     "%r.atZone(ZoneId.systemDefault()).minute",
+	"Second":     "%r.atZone(ZoneId.systemDefault()).second",
+	"Nanosecond": "%r.nano",
+}
+
+// timeImports são os imports exigidos pelos nomes curtos usados nos modelos acima
+var timeImports = map[string]string{
+	"Instant.":            "java.time.Instant",
+	"ZoneId.":             "java.time.ZoneId",
+	"DurationUnit.":       "kotlin.time.DurationUnit",
+	".toKotlinDuration()": "kotlin.time.toKotlinDuration",
+	".toJavaDuration()":   "kotlin.time.toJavaDuration",
+}
+
+// layoutChunks são os elementos dos layouts do Go e os padrões equivalentes
+// do DateTimeFormatter, na ordem em que devem ser tentados (mais longos antes)
+var layoutChunks = []struct{ goLayout, java string }{
+	{"January", "MMMM"}, {"Jan", "MMM"}, {"Monday", "EEEE"}, {"Mon", "EEE"}, {"MST", "z"},
+	{"2006", "yyyy"}, {"Z07:00", "XXX"}, {"Z0700", "XX"}, {"-07:00", "xxx"}, {"-0700", "xx"}, {"-07", "x"},
+	{".000000000", ".SSSSSSSSS"}, {".000000", ".SSSSSS"}, {".000", ".SSS"},
+	{".999999999", ".SSSSSSSSS"}, {".999999", ".SSSSSS"}, {".999", ".SSS"},
+	{"002", "DDD"}, {"01", "MM"}, {"02", "dd"}, {"_2", "ppd"}, {"03", "hh"}, {"04", "mm"}, {"05", "ss"},
+	{"06", "yy"}, {"15", "HH"}, {"PM", "a"}, {"pm", "a"},
+	{"1", "M"}, {"2", "d"}, {"3", "h"}, {"4", "m"}, {"5", "s"},
+}
+
+// isTimeType informa se typ é o tipo name do pacote time (ex: Duration)
+func isTimeType(typ types.Type, name string) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == name
+}
+
+// durationLiteral escreve uma duração constante na maior unidade exata (ex: 1500.milliseconds)
+func (t *Transpiler) durationLiteral(val constant.Value) (string, bool) {
+	ns, ok := constant.Int64Val(constant.ToInt(val))
+	if !ok {
+		return "", false
+	}
+	if ns == 0 {
+		t.useImport("kotlin.time.Duration")
+		return "Duration.ZERO", true
+	}
+	for _, unit := range durationUnits {
+		if ns%unit.ns != 0 {
+			continue
+		}
+		t.useImport("kotlin.time.Duration.Companion." + unit.name)
+		n := ns / unit.ns
+		if n != int64(int32(n)) {
+			return strconv.FormatInt(n, 10) + "L." + unit.name, true
+		}
+		return strconv.FormatInt(n, 10) + "." + unit.name, true
+	}
+	return "", false
+}
+
+// writeTimeTemplate escreve um modelo de timeFuncs, durationMethods ou
+// instantMethods registrando os imports dos nomes que ele usa
+func (t *Transpiler) writeTimeTemplate(tmpl string, recv ast.Expr, args []ast.Expr) {
+	for name, path := range timeImports {
+		if strings.Contains(tmpl, name) {
+			t.useImport(path)
+		}
+	}
+	t.writeCallTemplate(tmpl, recv, args)
+}
+
+// transpileTime traduz as chamadas ao pacote time e aos métodos de
+// time.Duration (kotlin.time.Duration) e time.Time (java.time.Instant)
+func (t *Transpiler) transpileTime(call *ast.CallExpr) bool {
+	if name := t.pkgMember(call.Fun, "time"); name != "" {
+		switch {
+		case name == "Now":
+			if t.monotonicNow[call] {
+				t.useImport("kotlin.time.TimeSource")
+				t.write("TimeSource.Monotonic.markNow()")
+			} else {
+				t.useImport("java.time.Instant")
+				t.write("Instant.now()")
+			}
+			return true
+		case name == "Since" && len(call.Args) == 1 && t.isMonotonic(call.Args[0]):
+			t.writeCallTemplate("%0.elapsedNow()", nil, call.Args)
+			return true
+		case timeFuncs[name] != "":
+			// Temporizadores e tickers (goAfter, GoTimer...) vêm do runtime
+			if sym, _, _ := strings.Cut(timeFuncs[name], "("); runtimeSymbols[sym] != "" {
+				t.useRuntime(sym)
+			}
+			t.writeTimeTemplate(timeFuncs[name], nil, call.Args)
+			return true
+		}
+		t.diagnose(call.Pos(), "time."+name+" sem tradução para Kotlin; chamada mantida")
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	recv := t.typeOf(sel.X)
+	switch {
+	case recv == nil:
+		return false
+	case isTimeType(recv, "Duration"):
+		if tmpl, ok := durationMethods[sel.Sel.Name]; ok {
+			t.writeTimeTemplate(tmpl, sel.X, call.Args)
+			return true
+		}
+		t.diagnose(call.Pos(), "time.Duration."+sel.Sel.Name+" sem tradução para Kotlin; chamada mantida")
+	case isTimeType(recv, "Time"):
+		if sel.Sel.Name == "Format" && len(call.Args) == 1 {
+			return t.transpileTimeFormat(sel.X, call.Args[0])
+		}
+		if tmpl, ok := instantMethods[sel.Sel.Name]; ok {
+			t.writeTimeTemplate(tmpl, sel.X, call.Args)
+			return true
+		}
+		t.diagnose(call.Pos(), "time.Time."+sel.Sel.Name+" sem tradução para Kotlin; chamada mantida")
+	}
+	return false
+}
+
+// transpileTimeFormat traduz t.Format(layout) com layout constante para um DateTimeFormatter
+func (t *Transpiler) transpileTimeFormat(recv, layout ast.Expr) bool {
+	val, ok := t.constValue(layout)
+	if !ok || val.Kind() != constant.String {
+		t.diagnose(layout.Pos(), "time.Time.Format só é traduzido com layout constante; chamada mantida")
+		return false
+	}
+	t.useImport("java.time.format.DateTimeFormatter")
+	t.useImport("java.time.ZoneId")
+	t.write("DateTimeFormatter.ofPattern(" + quoteKotlinString(javaDatePattern(constant.StringVal(val))) + ").withZone(ZoneId.systemDefault()).format(")
+	t.Transpile(recv)
+	t.write(")")
+	return true
+}
+
+// javaDatePattern converte um layout do Go (2006-01-02 15:04:05) para um
+// padrão do DateTimeFormatter (yyyy-MM-dd HH:mm:ss). Letras fora dos
+// elementos do layout vão entre aspas simples.
+func javaDatePattern(layout string) string {
+	var b strings.Builder
+	quoted := false
+	for i := 0; i < len(layout); {
+		chunk, java := "", ""
+		for _, c := range layoutChunks {
+			if strings.HasPrefix(layout[i:], c.goLayout) {
+				chunk, java = c.goLayout, c.java
+				break
+			}
+		}
+		if chunk != "" {
+			if quoted {
+				b.WriteByte('\'')
+				quoted = false
+			}
+			b.WriteString(java)
+			i += len(chunk)
+			continue
+		}
+		c := layout[i]
+		switch {
+		case c == '\'':
+			b.WriteString("''")
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			if !quoted {
+				b.WriteByte('\'')
+				quoted = true
+			}
+			b.WriteByte(c)
+		default:
+			if quoted {
+				b.WriteByte('\'')
+				quoted = false
+			}
+			b.WriteByte(c)
+		}
+		i++
+	}
+	if quoted {
+		b.WriteByte('\'')
+	}
+	return b.String()
+}
+
+// transpileTimeSelector traduz as constantes do pacote time (time.Second,
+// time.RFC3339...) pelo valor: durações como 1.seconds e layouts como texto
+func (t *Transpiler) transpileTimeSelector(sel *ast.SelectorExpr) bool {
+	if t.pkgMember(sel, "time") == "" {
+		return false
+	}
+	tv, ok := t.info.Types[sel]
+	if !ok || tv.Value == nil {
+		return false
+	}
+	literal, ok := t.constLiteral(tv.Value, tv.Type)
+	if ok {
+		t.write(literal)
+	}
+	return ok
+}
+
+// transpileDurationOp traduz as operações com time.Duration. O Kotlin soma e
+// compara durações e as multiplica ou divide por números; nos demais casos
+// (duração * duração, duração / duração, %) a conta é feita em nanossegundos.
+func (t *Transpiler) transpileDurationOp(n *ast.BinaryExpr) bool {
+	typ := t.typeOf(n)
+	if typ == nil || !isTimeType(typ, "Duration") {
+		return false
+	}
+	if val, ok := t.constValue(n); ok {
+		if literal, ok := t.durationLiteral(val); ok {
+			t.write(literal)
+			return true
+		}
+	}
+	switch {
+	case n.Op == token.ADD || n.Op == token.SUB:
+		t.Transpile(n.X)
+		t.write(" " + n.Op.String() + " ")
+		t.Transpile(n.Y)
+		return true
+	case n.Op == token.MUL && t.durationCount(n.X) != nil:
+		t.writeDurationCount(n.X)
+		t.write(" * ")
+		t.Transpile(n.Y)
+		return true
+	case (n.Op == token.MUL || n.Op == token.QUO) && t.durationCount(n.Y) != nil:
+		t.Transpile(n.X)
+		t.write(" " + n.Op.String() + " ")
+		t.writeDurationCount(n.Y)
+		return true
+	}
+	t.useImport("kotlin.time.Duration.Companion.nanoseconds")
+	t.write("(")
+	for i, side := range []ast.Expr{n.X, n.Y} {
+		if i > 0 {
+			t.write(" " + n.Op.String() + " ")
+		}
+		if t.durationCount(side) != nil {
+			t.writeDurationCount(side)
+		} else if val, ok := t.constValue(side); ok {
+			n, _ := constant.Int64Val(constant.ToInt(val))
+			t.write(strconv.FormatInt(n, 10) + "L")
+		} else {
+			t.transpileOperand(side)
+			t.write(".inWholeNanoseconds")
+		}
+	}
+	t.write(").nanoseconds")
+	return true
+}
+
+// durationCount devolve o número que multiplica uma duração: um literal ou
+// constante não tipada (2 em 2 * time.Second) ou o argumento Int ou Double
+// de uma conversão time.Duration(n)
+func (t *Transpiler) durationCount(expr ast.Expr) ast.Expr {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.BasicLit:
+		if val, ok := t.constValue(e); ok && val.Kind() == constant.Int {
+			if n, ok := constant.Int64Val(val); ok && n == int64(int32(n)) {
+				return e
+			}
+		}
+	case *ast.Ident:
+		if c, ok := t.objectOf(e).(*types.Const); ok {
+			if b, ok := c.Type().(*types.Basic); ok && b.Kind() == types.UntypedInt {
+				return e
+			}
+		}
+	case *ast.CallExpr:
+		tv, ok := t.info.Types[e.Fun]
+		if !ok || !tv.IsType() || len(e.Args) != 1 || !isTimeType(tv.Type, "Duration") {
+			return nil
+		}
+		if b, ok := t.typeOf(e.Args[0]).Underlying().(*types.Basic); ok {
+			if kt := kotlinBasicType(b, nil); kt == "Int" || kt == "Double" {
+				return e.Args[0]
+			}
+		}
+	}
+	return nil
+}
+
+// writeDurationCount escreve o número devolvido por durationCount
+func (t *Transpiler) writeDurationCount(expr ast.Expr) {
+	count := t.durationCount(expr)
+	if val, ok := t.constValue(count); ok {
+		n, _ := constant.Int64Val(constant.ToInt(val))
+		t.write(strconv.FormatInt(n, 10))
+		return
+	}
+	t.transpileOperand(count)
+}
+
+// transpileDurationConversion traduz conversões entre números e
+// time.Duration, que no Go conta nanossegundos
+func (t *Transpiler) transpileDurationConversion(arg ast.Expr, src, dst types.Type) bool {
+	switch {
+	case isTimeType(dst, "Duration") && !isTimeType(src, "Duration"):
+		t.useImport("kotlin.time.Duration.Companion.nanoseconds")
+		t.transpileOperand(arg)
+		t.write(".nanoseconds")
+		return true
+	case isTimeType(src, "Duration") && !isTimeType(dst, "Duration"):
+		b, ok := dst.Underlying().(*types.Basic)
+		if !ok || b.Info()&types.IsNumeric == 0 {
+			return false
+		}
+		t.transpileOperand(arg)
+		t.write(".inWholeNanoseconds")
+		if kt := kotlinBasicType(b, nil); kt != "Long" {
+			t.write(".to" + kt + "()")
+		}
+		return true
+	}
+	return false
+}
+
+// collectMonotonic encontra as variáveis start := time.Now() usadas apenas em
+// time.Since(start): elas viram marcas do TimeSource.Monotonic e
+// time.Since(start) vira start.elapsedNow()
+func (t *Transpiler) collectMonotonic(file *ast.File) {
+	if t.info == nil {
+		return
+	}
+	calls := make(map[types.Object]*ast.CallExpr)
+	ast.Inspect(file, func(node ast.Node) bool {
+		assign, ok := node.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return true
+		}
+		id, isIdent := assign.Lhs[0].(*ast.Ident)
+		call, isCall := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+		if isIdent && isCall && t.pkgMember(call.Fun, "time") == "Now" {
+			if obj := t.info.Defs[id]; obj != nil && !t.reassigned[obj] {
+				calls[obj] = call
+			}
+		}
+		return true
+	})
+	if len(calls) == 0 {
+		return
+	}
+	uses := make(map[types.Object]int)
+	for _, obj := range t.info.Uses {
+		if calls[obj] != nil {
+			uses[obj]++
+		}
+	}
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 || t.pkgMember(call.Fun, "time") != "Since" {
+			return true
+		}
+		if id, ok := ast.Unparen(call.Args[0]).(*ast.Ident); ok && calls[t.objectOf(id)] != nil {
+			uses[t.objectOf(id)]--
+		}
+		return true
+	})
+	for obj, call := range calls {
+		if uses[obj] == 0 {
+			t.monotonic[obj] = true
+			t.monotonicNow[call] = true
+		}
+	}
+}
+
+// isMonotonic informa se expr é uma variável marcada por collectMonotonic
+func (t *Transpiler) isMonotonic(expr ast.Expr) bool {
+	id, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && t.monotonic[t.objectOf(id)]
+}
@@ -0,0 +1,141 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"strings"
+	"sync"
+)
+
+// goImporter resolve os pacotes importados a partir do código-fonte do GOROOT.
+// Quando o GOROOT não está disponível (ex: deploy serverless), devolve um
+// pacote vazio: a checagem continua e apenas os símbolos externos ficam sem
+// tipo. Os pacotes nessa situação ficam em failed, para serem avisados.
+type goImporter struct {
+	mu     sync.Mutex
+	source types.Importer
+	cache  map[string]*types.Package
+	failed map[string]error
+}
+
+var stdImporter = &goImporter{cache: make(map[string]*types.Package), failed: make(map[string]error)}
+
+func (g *goImporter) Import(path string) (*types.Package, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if pkg, ok := g.cache[path]; ok {
+		return pkg, nil
+	}
+	if g.source == nil {
+		g.source = importer.ForCompiler(token.NewFileSet(), "source", nil)
+	}
+	pkg, err := g.source.Import(path)
+	if err != nil {
+		name := path
+		for i := len(path) - 1; i >= 0; i-- {
+			if path[i] == '/' {
+				name = path[i+1:]
+				break
+			}
+		}
+		pkg = types.NewPackage(path, name)
+		pkg.MarkComplete()
+		g.failed[path] = err
+	}
+	g.cache[path] = pkg
+	return pkg, nil
+}
+
+// importError devolve o erro da importação do pacote, se ela falhou
+func (g *goImporter) importError(path string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.failed[path]
+}
+
+// typeCheck executa o go/types sobre o arquivo e guarda as informações de tipo
+// usadas pelos handlers. Erros de tipo não interrompem a transpilação.
+func (t *Transpiler) typeCheck(file *ast.File) {
+	t.info = &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:     make(map[ast.Node]*types.Scope),
+	}
+	conf := types.Config{
+		Importer: stdImporter,
+		Error:    func(err error) {},
+	}
+	t.pkg, _ = conf.Check(file.Name.Name, t.fset, []*ast.File{file}, t.info)
+	for _, spec := range file.Imports {
+		path := strings.Trim(spec.Path.Value, `"`)
+		if err := stdImporter.importError(path); err != nil {
+			t.diagnose(spec.Pos(), "pacote "+path+" não pôde ser analisado ("+strings.TrimSpace(err.Error())+"); os usos dele ficam sem tipo e a tradução pode ficar incompleta")
+		}
+	}
+}
+
+// typeOf devolve o tipo de uma expressão, ou nil se for desconhecido
+func (t *Transpiler) typeOf(expr ast.Expr) types.Type {
+	if t.info == nil || expr == nil {
+		return nil
+	}
+	if tv, ok := t.info.Types[expr]; ok && tv.Type != nil {
+		if b, ok := tv.Type.(*types.Basic); ok && b.Kind() == types.Invalid {
+			return nil
+		}
+		return tv.Type
+	}
+	if id, ok := expr.(*ast.Ident); ok {
+		if obj := t.objectOf(id); obj != nil && obj.Type() != nil {
+			if b, ok := obj.Type().(*types.Basic); !ok || b.Kind() != types.Invalid {
+				return obj.Type()
+			}
+		}
+	}
+	return nil
+}
+
+// objectOf devolve o objeto (variável, constante, tipo...) referenciado por um identificador
+func (t *Transpiler) objectOf(id *ast.Ident) types.Object {
+	if t.info == nil {
+		return nil
+	}
+	if obj, ok := t.info.Defs[id]; ok && obj != nil {
+		return obj
+	}
+	return t.info.Uses[id]
+}
+
+// isPackageLevel informa se o objeto foi declarado no escopo do pacote
+func (t *Transpiler) isPackageLevel(obj types.Object) bool {
+	return t.pkg != nil && obj != nil && obj.Parent() == t.pkg.Scope()
+}
+
+// pkgMember devolve o nome do membro do pacote importado path referenciado
+// pela expressão (ex: "Contains" em strings.Contains), ou "" se for outra coisa
+func (t *Transpiler) pkgMember(expr ast.Expr, path string) string {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	if pkg, isPkg := t.objectOf(x).(*types.PkgName); isPkg {
+		if pkg.Imported().Path() == path {
+			return sel.Sel.Name
+		}
+		return ""
+	}
+	if t.info == nil && x.Name == path[strings.LastIndex(path, "/")+1:] {
+		return sel.Sel.Name
+	}
+	return ""
+}
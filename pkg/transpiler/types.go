@@ -2,6 +2,7 @@ package transpiler
 
 import (
 	"go/ast"
+	"go/types"
 	"strings"
 )
 
@@ -18,38 +19,54 @@ var typeMapping = map[string]string{
 	"uint64":  "ULong",
 	"float32": "Float",
 	"float64": "Double",
-	"complex64":  "Any /* Complex */",
-	"complex128": "Any /* Complex */",
+	"complex64":  "Complex",
+	"complex128": "Complex",
 	"byte":    "UByte",
 	"rune":    "Char",
 	"bool":    "Boolean",
 	"string":  "String",
 	"uintptr": "Long",
 	"any":     "Any",
+	"error":   "GoError",
 }
 
 func (t *Transpiler) resolveType(expr ast.Expr) string {
 	switch e := expr.(type) {
 	case *ast.Ident:
 		if val, ok := typeMapping[e.Name]; ok {
+			if _, ok := runtimeSymbols[val]; ok {
+				t.useRuntime(val)
+			}
 			return val
 		}
-		return e.Name
+		return t.ident(e)
 
 	case *ast.ArrayType:
 		inner := t.resolveType(e.Elt)
 		return "MutableList<" + inner + ">"
 
+	case *ast.Ellipsis:
+		// Fora de uma lista de parâmetros, ...T é tratado como o slice []T
+		return "MutableList<" + t.resolveType(e.Elt) + ">"
+
 	case *ast.MapType:
 		key := t.resolveType(e.Key)
 		val := t.resolveType(e.Value)
 		return "MutableMap<" + key + ", " + val + ">"
 
 	case *ast.StarExpr:
+		// Ponteiros para structs são referências anuláveis; os demais viram caixas Ref
+		if elem := t.typeOf(e.X); elem != nil && !isStructType(elem) {
+			t.useRuntime("Ref")
+			return "Ref<" + t.resolveType(e.X) + ">?"
+		}
 		return t.resolveType(e.X) + "?"
 
 	case *ast.SelectorExpr:
-		return t.resolveType(e.X) + "." + e.Sel.Name
+		if kt, ok := t.stdType(t.typeOf(e)); ok {
+			return kt
+		}
+		return t.resolveType(e.X) + "." + kotlinName(e.Sel.Name)
 
 	case *ast.InterfaceType:
 		if e.Methods == nil || len(e.Methods.List) == 0 {
@@ -70,14 +87,64 @@ func (t *Transpiler) resolveType(expr ast.Expr) string {
 			}
 		}
 		
-		ret := "Unit"
-		if e.Results != nil && len(e.Results.List) > 0 {
-			ret = t.resolveType(e.Results.List[0].Type)
-		}
-		
-		return "(" + strings.Join(params, ", ") + ") -> " + ret
+		return "(" + strings.Join(params, ", ") + ") -> " + t.resultsType(e.Results)
 
 	default:
 		return "Any"
 	}
+}
+
+// resolveGoType converte um tipo já checado pelo go/types para Kotlin,
+// usado quando o tipo não aparece escrito no código (ex: constantes implícitas)
+func (t *Transpiler) resolveGoType(typ types.Type) string {
+	switch tt := typ.(type) {
+	case *types.Basic:
+		if tt.Kind() == types.Invalid {
+			// Tipo que o go/types não resolveu (ex: pacote importado sem o GOROOT)
+			return "Any?"
+		}
+		return kotlinBasicType(tt, nil)
+	case *types.Named:
+		obj := tt.Obj()
+		if obj.Pkg() == nil {
+			if obj.Name() == "error" {
+				t.useRuntime("GoError")
+				return "GoError"
+			}
+			return obj.Name()
+		}
+		if kt, ok := t.stdType(tt); ok {
+			return kt
+		}
+		if obj.Pkg() != t.pkg {
+			return obj.Pkg().Name() + "." + obj.Name()
+		}
+		return t.objName(obj)
+	case *types.Alias:
+		return t.resolveGoType(types.Unalias(tt))
+	case *types.Pointer:
+		if !isStructType(tt.Elem()) {
+			t.useRuntime("Ref")
+			return "Ref<" + t.resolveGoType(tt.Elem()) + ">?"
+		}
+		return t.resolveGoType(tt.Elem()) + "?"
+	case *types.Slice:
+		return "MutableList<" + t.resolveGoType(tt.Elem()) + ">"
+	case *types.Array:
+		return "MutableList<" + t.resolveGoType(tt.Elem()) + ">"
+	case *types.Map:
+		return "MutableMap<" + t.resolveGoType(tt.Key()) + ", " + t.resolveGoType(tt.Elem()) + ">"
+	case *types.Chan:
+		return "Channel<" + t.resolveGoType(tt.Elem()) + ">"
+	case *types.TypeParam:
+		return t.objName(tt.Obj())
+	case *types.Signature:
+		var params []string
+		for i := 0; i < tt.Params().Len(); i++ {
+			params = append(params, t.resolveGoType(tt.Params().At(i).Type()))
+		}
+		return "(" + strings.Join(params, ", ") + ") -> " + t.goResultsType(tt.Results())
+	default:
+		return "Any"
+	}
 }
\ No newline at end of file
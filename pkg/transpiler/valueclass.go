@@ -0,0 +1,211 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// collectValueClasses identifica os tipos definidos sobre tipos básicos
+// (ex: type Celsius float64), que viram value classes no Kotlin
+func (t *Transpiler) collectValueClasses(file *ast.File) {
+	if t.info == nil {
+		return
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok || ts.Assign.IsValid() || t.enums[ts.Name.Name] {
+				continue
+			}
+			obj, ok := t.objectOf(ts.Name).(*types.TypeName)
+			if !ok {
+				continue
+			}
+			if _, ok := obj.Type().Underlying().(*types.Basic); ok {
+				t.valueClasses[ts.Name.Name] = true
+				t.memberTypes[ts.Name.Name] = true
+			}
+		}
+	}
+}
+
+// valueClassOf devolve o tipo nomeado se ele for traduzido como value class
+func (t *Transpiler) valueClassOf(typ types.Type) *types.Named {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() != t.pkg || !t.valueClasses[named.Obj().Name()] {
+		return nil
+	}
+	return named
+}
+
+// receiverTypeName devolve o nome do tipo base do receiver de um método
+func receiverTypeName(fd *ast.FuncDecl) string {
+	if fd.Recv == nil || len(fd.Recv.List) == 0 {
+		return ""
+	}
+	expr := fd.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if idx, ok := expr.(*ast.IndexExpr); ok {
+		expr = idx.X
+	}
+	if id, ok := expr.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+// writeValueClass gera a value class com os operadores do tipo básico
+// subjacente e os métodos declarados para o tipo como membros
+func (t *Transpiler) writeValueClass(ts *ast.TypeSpec) {
+	name := ts.Name.Name
+	named := t.objectOf(ts.Name).Type().(*types.Named)
+	basic := named.Underlying().(*types.Basic)
+	inner := kotlinBasicType(basic, nil)
+	info := basic.Info()
+	ordered := info&types.IsOrdered != 0 && inner != "Char"
+
+	if t.serializable[name] {
+		t.writeLine("@Serializable")
+	}
+	t.writeLine("@JvmInline")
+	t.writeIndent()
+	t.write("value class " + name + "(val value: " + inner + ")")
+	if ordered {
+		t.writeSupertypes(name, "Comparable<"+name+">")
+	} else {
+		t.writeSupertypes(name)
+	}
+	t.write(" {\n")
+	t.indent()
+
+	wrap := func(expr string) string {
+		return name + "(" + narrowTo(inner, expr) + ")"
+	}
+	if info&types.IsNumeric != 0 && inner != "Char" {
+		t.writeLine("operator fun plus(other: " + name + ") = " + wrap("value + other.value"))
+		t.writeLine("operator fun minus(other: " + name + ") = " + wrap("value - other.value"))
+		t.writeLine("operator fun times(other: " + name + ") = " + wrap("value * other.value"))
+		t.writeLine("operator fun div(other: " + name + ") = " + wrap("value / other.value"))
+		if info&types.IsInteger != 0 {
+			t.writeLine("operator fun rem(other: " + name + ") = " + wrap("value % other.value"))
+		}
+		if info&types.IsUnsigned == 0 {
+			t.writeLine("operator fun unaryMinus() = " + wrap("-value"))
+		}
+		one := "1"
+		if info&types.IsUnsigned != 0 {
+			one = "1u"
+		}
+		t.writeLine("operator fun inc() = " + wrap("value + "+one))
+		t.writeLine("operator fun dec() = " + wrap("value - "+one))
+	}
+	if info&types.IsString != 0 {
+		t.writeLine("operator fun plus(other: " + name + ") = " + name + "(value + other.value)")
+	}
+	if info&types.IsBoolean != 0 {
+		t.writeLine("operator fun not() = " + name + "(!value)")
+	}
+	if ordered {
+		t.writeLine("override fun compareTo(other: " + name + ") = value.compareTo(other.value)")
+	}
+
+	// Go imprime o valor subjacente, ou o resultado de String() se existir
+	if method, _, _ := types.LookupFieldOrMethod(named, true, t.pkg, "String"); method != nil {
+		t.writeLine("override fun toString() = String()")
+	} else {
+		t.writeLine("override fun toString() = value.toString()")
+	}
+
+	if t.hasMembers(name) {
+		t.write("\n")
+		t.writeMembers(name)
+	}
+
+	t.unindent()
+	t.writeIndent()
+	t.write("}")
+}
+
+// narrowTo devolve a conversão necessária para voltar ao tipo original,
+// já que aritmética com Byte e Short resulta em Int no Kotlin
+func narrowTo(ktType, expr string) string {
+	switch ktType {
+	case "Byte", "Short", "UByte", "UShort":
+		return "(" + expr + ").to" + ktType + "()"
+	}
+	return expr
+}
+
+// transpileValueClassConst escreve uma constante não tipada usada onde se
+// espera uma value class, envolvendo o literal no construtor (ex: Celsius(100.0))
+func (t *Transpiler) transpileValueClassConst(expr ast.Expr) bool {
+	if t.info == nil {
+		return false
+	}
+	tv, ok := t.info.Types[expr]
+	if !ok || tv.Value == nil {
+		return false
+	}
+	named := t.valueClassOf(tv.Type)
+	if named == nil {
+		return false
+	}
+	if id, ok := expr.(*ast.Ident); ok {
+		if obj := t.objectOf(id); obj != nil && t.valueClassOf(obj.Type()) != nil {
+			return false
+		}
+	}
+	literal, ok := t.constLiteral(tv.Value, named)
+	if !ok {
+		return false
+	}
+	t.write(t.objName(named.Obj()) + "(" + literal + ")")
+	return true
+}
+
+// isValueReceiver informa se a expressão é o receiver ponteiro de um método
+// de value class, que no Kotlin é o próprio valor (this) e não uma caixa Ref
+func (t *Transpiler) isValueReceiver(expr ast.Expr) bool {
+	id, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := t.objectOf(id).(*types.Var)
+	if !ok || v.Kind() != types.RecvVar {
+		return false
+	}
+	ptr, ok := v.Type().(*types.Pointer)
+	return ok && t.valueClassOf(ptr.Elem()) != nil
+}
+
+// writesValueReceiver informa se o método atribui a *c, sendo c o receiver
+// ponteiro de uma value class
+func (t *Transpiler) writesValueReceiver(fd *ast.FuncDecl) bool {
+	if t.info == nil || fd.Body == nil {
+		return false
+	}
+	writes := false
+	written := func(expr ast.Expr) {
+		if star, ok := ast.Unparen(expr).(*ast.StarExpr); ok && t.isValueReceiver(star.X) {
+			writes = true
+		}
+	}
+	ast.Inspect(fd.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				written(lhs)
+			}
+		case *ast.IncDecStmt:
+			written(n.X)
+		}
+		return !writes
+	})
+	return writes
+}
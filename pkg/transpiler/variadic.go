@@ -0,0 +1,132 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// primitiveArrays associa os tipos Kotlin cujo vararg é um array primitivo
+// à conversão usada para espalhar uma lista (ex: vararg Int é um IntArray)
+var primitiveArrays = map[string]string{
+	"Int":     "toIntArray",
+	"Long":    "toLongArray",
+	"Short":   "toShortArray",
+	"Byte":    "toByteArray",
+	"Double":  "toDoubleArray",
+	"Float":   "toFloatArray",
+	"Boolean": "toBooleanArray",
+	"Char":    "toCharArray",
+	"UInt":    "toUIntArray",
+	"ULong":   "toULongArray",
+	"UShort":  "toUShortArray",
+	"UByte":   "toUByteArray",
+}
+
+// writeVarargParam escreve um parâmetro variádico (nums ...int) como vararg
+func (t *Transpiler) writeVarargParam(name string, ellipsis *ast.Ellipsis) {
+	elemType := t.resolveType(ellipsis.Elt)
+	if elem := t.typeOf(ellipsis.Elt); elem != nil {
+		elemType = nullableType(elemType, elem)
+	}
+	t.write("vararg " + name + ": " + elemType)
+}
+
+// varargPrelude devolve a declaração que converte o vararg em lista, já que
+// no Go o parâmetro variádico é um slice comum
+func (t *Transpiler) varargPrelude(fields *ast.FieldList) []string {
+	if fields == nil || len(fields.List) == 0 {
+		return nil
+	}
+	last := fields.List[len(fields.List)-1]
+	if _, ok := last.Type.(*ast.Ellipsis); !ok || len(last.Names) == 0 || last.Names[0].Name == "_" {
+		return nil
+	}
+	name := last.Names[0]
+	return []string{t.declKeyword(name) + " " + t.ident(name) + " = " + t.ident(name) + ".toMutableList()"}
+}
+
+// writeCallArgs escreve os argumentos de uma chamada. Em f(xs...) o último
+// argumento é espalhado no vararg com o operador * do Kotlin.
+func (t *Transpiler) writeCallArgs(call *ast.CallExpr) {
+	t.write("(")
+	for i, arg := range call.Args {
+		if i > 0 {
+			t.write(", ")
+		}
+		if i == len(call.Args)-1 && call.Ellipsis.IsValid() {
+			t.write("*")
+			t.transpileOperand(arg)
+			t.write("." + t.spreadConversion(arg) + "()")
+			continue
+		}
+		t.Transpile(arg)
+	}
+	t.write(")")
+}
+
+// spreadConversion devolve a conversão da lista para o array aceito pelo
+// vararg: arrays primitivos para tipos básicos e toTypedArray para os demais
+func (t *Transpiler) spreadConversion(arg ast.Expr) string {
+	typ := t.typeOf(arg)
+	if typ == nil {
+		return "toTypedArray"
+	}
+	slice, ok := typ.Underlying().(*types.Slice)
+	if !ok {
+		return "toTypedArray"
+	}
+	if nullableZero(slice.Elem()) {
+		return "toTypedArray"
+	}
+	if conv, ok := primitiveArrays[t.resolveGoType(slice.Elem())]; ok {
+		return conv
+	}
+	return "toTypedArray"
+}
+
+// transpileAppend traduz append: os elementos (ou a lista espalhada com ...)
+// são concatenados e o resultado volta a ser uma MutableList
+func (t *Transpiler) transpileAppend(call *ast.CallExpr) bool {
+	if len(call.Args) < 2 {
+		if len(call.Args) == 1 {
+			t.Transpile(call.Args[0])
+			return true
+		}
+		return false
+	}
+	t.write("(")
+	t.transpileOperand(call.Args[0])
+	t.write(" + ")
+	rest := call.Args[1:]
+	switch {
+	case call.Ellipsis.IsValid():
+		t.transpileOperand(rest[0])
+		if typ := t.typeOf(rest[0]); typ != nil {
+			if b, ok := typ.Underlying().(*types.Basic); ok && b.Info()&types.IsString != 0 {
+				// append([]byte, s...) acrescenta os bytes UTF-8 da string
+				t.write(".toByteArray().map { it.toUByte() }")
+			}
+		}
+	case len(rest) == 1 && !t.isCollection(rest[0]):
+		t.transpileOperand(rest[0])
+	default:
+		// listOf evita que um elemento que também é lista seja concatenado
+		t.write("listOf")
+		t.writeElements(rest)
+	}
+	t.write(").toMutableList()")
+	return true
+}
+
+// isCollection informa se a expressão é um slice, array ou map
+func (t *Transpiler) isCollection(expr ast.Expr) bool {
+	typ := t.typeOf(expr)
+	if typ == nil {
+		return false
+	}
+	switch typ.Underlying().(type) {
+	case *types.Slice, *types.Array, *types.Map:
+		return true
+	}
+	return false
+}
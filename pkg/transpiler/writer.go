@@ -2,9 +2,12 @@ package transpiler
 
 import (
 	"bytes"
+	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -17,13 +20,78 @@ type StructDef struct {
 	Embeds []string
 }
 
+// Diagnostic descreve um trecho do código Go que não pôde ser traduzido com segurança
+type Diagnostic struct {
+	Pos     token.Position
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	if d.Pos.IsValid() {
+		return fmt.Sprintf("%d:%d: %s", d.Pos.Line, d.Pos.Column, d.Message)
+	}
+	return d.Message
+}
+
+// Options controla traduções opcionais
+type Options struct {
+	// EnumClasses converte grupos de constantes de um tipo inteiro nomeado
+	// que possui método String() em uma enum class do Kotlin
+	EnumClasses bool `json:"enumClasses"`
+	// Utf8Len faz len(s) contar os bytes UTF-8 da string, como no Go, em vez
+	// das unidades UTF-16 de String.length
+	Utf8Len bool `json:"utf8Len"`
+	// ValFields declara como val os campos de struct que nunca são
+	// alterados depois da construção
+	ValFields bool `json:"valFields"`
+	// GoOutput imprime os valores como o fmt do Go ({1 2}, [1 2], map[a:1])
+	// usando o goFormat do arquivo de suporte GoFormat.kt
+	GoOutput bool `json:"goOutput"`
+	// Runtime importa do go2kt-runtime (pacote go2kt.runtime) as declarações
+	// de suporte usadas, em vez de declará-las no próprio arquivo; os fontes
+	// do runtime são devolvidos em SupportFiles
+	Runtime bool `json:"runtime"`
+}
+
 // Transpiler agora possui um mapa de estratégias (handlers)
 type Transpiler struct {
 	fset           *token.FileSet
 	output         bytes.Buffer
 	indentLevel    int
+	options        Options
 	structs        map[string]StructDef
 	vars           map[string]string
+	enums          map[string]bool
+	valueClasses   map[string]bool
+	interfaces     map[string]bool
+	classes        map[string]*classInfo
+	memberTypes    map[string]bool
+	runtime        map[string]bool
+	imports        map[string]bool
+	diagnostics    []Diagnostic
+
+	// Análise de ponteiros: variáveis guardadas em Ref e ponteiros nunca nulos
+	boxed          map[types.Object]bool
+	nonNull        map[types.Object]bool
+	nullable       map[types.Object]bool
+	reassigned     map[types.Object]bool
+	renames        map[types.Object]string
+
+	// Arquivo em tradução e classe cujos métodos estão sendo emitidos como membros
+	file           *ast.File
+	memberOf       string
+	fn             *funcContext
+	sortKey        *sortKey
+	scanLines      map[types.Object]string
+	monotonic      map[types.Object]bool
+	monotonicNow   map[*ast.CallExpr]bool
+	serializable   map[string]bool
+	tempCount      int
+	usedNames      map[string]bool
+
+	// Informações do go/types (preenchidas em handleFile)
+	info           *types.Info
+	pkg            *types.Package
 	
 	// Mapa de Estratégias (Tipo do Nó -> Função de Tratamento)
 	handlers       map[string]HandlerFunc
@@ -31,17 +99,43 @@ type Transpiler struct {
 	// Flags de Análise
 	usesCoroutines bool
 	usesChannels   bool
+	usesSerialization bool
+	usesRecover    bool
 }
 
 // NewTranspiler inicializa e REGISTRA as estratégias
 func NewTranspiler() *Transpiler {
+	return NewTranspilerWithOptions(Options{})
+}
+
+// NewTranspilerWithOptions inicializa o transpilador com as opções informadas
+func NewTranspilerWithOptions(opts Options) *Transpiler {
 	t := &Transpiler{
 		fset:           token.NewFileSet(),
+		options:        opts,
 		structs:        make(map[string]StructDef),
 		vars:           make(map[string]string),
+		enums:          make(map[string]bool),
+		valueClasses:   make(map[string]bool),
+		interfaces:     make(map[string]bool),
+		classes:        make(map[string]*classInfo),
+		memberTypes:    make(map[string]bool),
+		runtime:        make(map[string]bool),
+		imports:        make(map[string]bool),
+		boxed:          make(map[types.Object]bool),
+		nonNull:        make(map[types.Object]bool),
+		nullable:       make(map[types.Object]bool),
+		reassigned:     make(map[types.Object]bool),
+		renames:        make(map[types.Object]string),
+		scanLines:      make(map[types.Object]string),
+		monotonic:      make(map[types.Object]bool),
+		monotonicNow:   make(map[*ast.CallExpr]bool),
+		serializable:   make(map[string]bool),
 		handlers:       make(map[string]HandlerFunc),
 		usesCoroutines: false,
 		usesChannels:   false,
+		usesSerialization: false,
+		usesRecover:    false,
 	}
 	
 	// Inicializa o mapa de handlers
@@ -50,11 +144,51 @@ func NewTranspiler() *Transpiler {
 	return t
 }
 
+// FileSet retorna o FileSet que deve ser usado no parsing do código Go,
+// para que as posições da AST sejam válidas na checagem de tipos
+func (t *Transpiler) FileSet() *token.FileSet {
+	return t.fset
+}
+
 // GetOutput retorna o código gerado
 func (t *Transpiler) GetOutput() string {
 	return t.output.String()
 }
 
+// SupportFiles retorna os arquivos Kotlin que devem acompanhar a saída,
+// indexados pelo nome do arquivo
+func (t *Transpiler) SupportFiles() map[string]string {
+	files := make(map[string]string)
+	if t.options.Runtime {
+		t.runtimeSupportFiles(files)
+	} else if t.options.GoOutput && (t.runtime["goSprintf"] || t.runtime["goFormat"]) {
+		files[goFormatFile] = t.goFormatSupport()
+	}
+	return files
+}
+
+// Diagnostics retorna os avisos gerados durante a tradução
+func (t *Transpiler) Diagnostics() []Diagnostic {
+	return t.diagnostics
+}
+
+// diagnose registra um aviso associado a uma posição do código Go
+func (t *Transpiler) diagnose(pos token.Pos, msg string) {
+	t.diagnostics = append(t.diagnostics, Diagnostic{Pos: t.fset.Position(pos), Message: msg})
+}
+
+// newTemp devolve um nome novo para uma variável auxiliar do código gerado,
+// diferente de todos os identificadores do arquivo Go
+func (t *Transpiler) newTemp() string {
+	for {
+		t.tempCount++
+		name := "tmp" + strconv.Itoa(t.tempCount)
+		if !t.usedNames[name] {
+			return name
+		}
+	}
+}
+
 // --- Helper Methods (Indentação e Escrita) ---
 
 func (t *Transpiler) indent() { t.indentLevel++ }
@@ -0,0 +1,265 @@
+package transpiler
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// zeroValue devolve a expressão Kotlin equivalente ao valor zero do tipo Go
+func (t *Transpiler) zeroValue(typ types.Type) string {
+	switch tt := typ.(type) {
+	case *types.Alias:
+		return t.zeroValue(types.Unalias(tt))
+	case *types.Named:
+		name := tt.Obj().Name()
+		if t.enums[name] {
+			return t.enumZero(tt)
+		}
+		if t.valueClassOf(tt) != nil {
+			return name + "(" + t.zeroValue(tt.Underlying()) + ")"
+		}
+		if kt, ok := t.stdType(tt); ok {
+			if zero, ok := stdZeros[kt]; ok {
+				return zero
+			}
+			return kt + "()"
+		}
+		if st, ok := tt.Underlying().(*types.Struct); ok {
+			// Structs do pacote têm valores padrão no construtor
+			if tt.Obj().Pkg() == t.pkg {
+				return name + "()"
+			}
+			return t.resolveGoType(tt) + "(" + t.zeroFields(st) + ")"
+		}
+		if _, ok := tt.Underlying().(*types.Interface); ok {
+			return "null"
+		}
+		return t.zeroValue(tt.Underlying())
+	case *types.Basic:
+		switch {
+		case tt.Info()&types.IsBoolean != 0:
+			return "false"
+		case tt.Info()&types.IsString != 0:
+			return `""`
+		case kotlinBasicType(tt, nil) == "Char":
+			return `'\u0000'`
+		case narrowInt(tt):
+			// 0 e 0u são Int e UInt fora de um contexto com o tipo esperado
+			return "0.to" + kotlinBasicType(tt, nil) + "()"
+		case tt.Info()&types.IsNumeric != 0:
+			if literal, ok := t.constLiteral(constant.MakeInt64(0), tt); ok {
+				return literal
+			}
+		}
+		return "null"
+	case *types.Slice:
+		return "mutableListOf()"
+	case *types.Array:
+		return "MutableList(" + strconv.FormatInt(tt.Len(), 10) + ") { " + t.zeroValue(tt.Elem()) + " }"
+	}
+	return "null"
+}
+
+// collectNullable marca as variáveis declaradas sem inicializador e os campos
+// de struct cujo valor zero é null: o tipo Kotlin é anulável e os usos precisam de !!
+func (t *Transpiler) collectNullable(file *ast.File) {
+	if t.info == nil {
+		return
+	}
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.ValueSpec:
+			if len(n.Values) > 0 {
+				return true
+			}
+			for _, name := range n.Names {
+				if obj, ok := t.objectOf(name).(*types.Var); ok && nullableZero(obj.Type()) && !t.boxed[obj] {
+					t.nullable[obj] = true
+				}
+			}
+		case *ast.TypeSpec:
+			obj := t.objectOf(n.Name)
+			if obj == nil {
+				return true
+			}
+			st, ok := obj.Type().Underlying().(*types.Struct)
+			if !ok {
+				return true
+			}
+			for i := 0; i < st.NumFields(); i++ {
+				if f := st.Field(i); nullableZero(f.Type()) {
+					t.nullable[f] = true
+				}
+			}
+		}
+		return true
+	})
+}
+
+// nullableZero informa se o valor zero do tipo é null, exigindo um tipo
+// Kotlin anulável: ponteiros, maps, interfaces, channels e funções
+func nullableZero(typ types.Type) bool {
+	switch typ.Underlying().(type) {
+	case *types.Pointer, *types.Map, *types.Interface, *types.Chan, *types.Signature:
+		return true
+	}
+	return false
+}
+
+// nullableType devolve o tipo Kotlin anulável quando o valor zero é null
+func nullableType(ktType string, typ types.Type) string {
+	if typ != nil && nullableZero(typ) && !strings.HasSuffix(ktType, "?") {
+		if strings.Contains(ktType, "->") {
+			return "(" + ktType + ")?"
+		}
+		return ktType + "?"
+	}
+	return ktType
+}
+
+// zeroFields devolve os valores zero de todos os campos de uma struct, na ordem
+func (t *Transpiler) zeroFields(st *types.Struct) string {
+	var fields []string
+	for i := 0; i < st.NumFields(); i++ {
+		fields = append(fields, t.zeroValue(st.Field(i).Type()))
+	}
+	return strings.Join(fields, ", ")
+}
+
+// enumZero devolve a entrada da enum class com valor 0 (ou a primeira entrada)
+func (t *Transpiler) enumZero(named *types.Named) string {
+	if entry := t.enumEntry(named, constant.MakeInt64(0)); entry != "" {
+		return entry
+	}
+	return t.objName(named.Obj()) + ".entries.first()"
+}
+
+// transpileTypedCompositeLit traduz literais compostos usando o tipo checado,
+// o que também cobre literais com tipo omitido (ex: []Point{{1, 2}})
+func (t *Transpiler) transpileTypedCompositeLit(n *ast.CompositeLit) bool {
+	typ := t.typeOf(n)
+	if typ == nil {
+		return false
+	}
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Struct:
+		if _, ok := typ.(*types.Named); !ok {
+			return false
+		}
+		t.transpileStructLit(n, typ)
+	case *types.Array:
+		t.transpileArrayLit(n, u)
+	case *types.Slice:
+		if hasKeys(n) {
+			return false
+		}
+		t.write("mutableListOf")
+		if len(n.Elts) == 0 || nullableZero(u.Elem()) || narrowInt(u.Elem()) {
+			t.write("<" + t.resolveGoType(u.Elem()) + ">")
+		}
+		t.writeElements(n.Elts)
+	case *types.Map:
+		t.write("mutableMapOf")
+		if len(n.Elts) == 0 {
+			t.write("<" + t.resolveGoType(u.Key()) + ", " + t.resolveGoType(u.Elem()) + ">")
+		}
+		t.writeElements(n.Elts)
+	default:
+		return false
+	}
+	return true
+}
+
+// transpileArrayLit traduz um literal de array de tamanho fixo, preenchendo
+// as posições omitidas com o valor zero do elemento
+func (t *Transpiler) transpileArrayLit(n *ast.CompositeLit, arr *types.Array) {
+	zero := t.zeroValue(arr.Elem())
+	missing := int(arr.Len()) - len(n.Elts)
+	if !hasKeys(n) && missing <= 8 {
+		t.write("mutableListOf")
+		if narrowInt(arr.Elem()) {
+			t.write("<" + t.resolveGoType(arr.Elem()) + ">")
+		}
+		t.write("(")
+		for i, elt := range n.Elts {
+			if i > 0 {
+				t.write(", ")
+			}
+			t.transpileCopied(elt)
+		}
+		for i := 0; i < missing; i++ {
+			if i > 0 || len(n.Elts) > 0 {
+				t.write(", ")
+			}
+			t.write(zero)
+		}
+		t.write(")")
+		return
+	}
+
+	t.write("MutableList(" + strconv.FormatInt(arr.Len(), 10) + ") { " + zero + " }")
+	if len(n.Elts) == 0 {
+		return
+	}
+	t.write(".also {\n")
+	t.indent()
+	index := int64(0)
+	for _, elt := range n.Elts {
+		value := elt
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if tv, ok := t.info.Types[kv.Key]; ok && tv.Value != nil {
+				index, _ = constant.Int64Val(constant.ToInt(tv.Value))
+			}
+			value = kv.Value
+		}
+		t.writeIndent()
+		t.write("it[" + strconv.FormatInt(index, 10) + "] = ")
+		t.transpileCopied(value)
+		t.write("\n")
+		index++
+	}
+	t.unindent()
+	t.writeIndent()
+	t.write("}")
+}
+
+// writeElements escreve os elementos de um literal entre parênteses
+func (t *Transpiler) writeElements(elts []ast.Expr) {
+	t.write("(")
+	for i, elt := range elts {
+		if i > 0 {
+			t.write(", ")
+		}
+		t.transpileCopied(elt)
+	}
+	t.write(")")
+}
+
+func hasKeys(n *ast.CompositeLit) bool {
+	for _, elt := range n.Elts {
+		if _, ok := elt.(*ast.KeyValueExpr); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// narrowInt informa se o tipo vira Byte, Short, UByte ou UShort no Kotlin,
+// tipos que os literais inteiros só assumem quando o tipo esperado é conhecido
+func narrowInt(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	switch kotlinBasicType(basic, nil) {
+	case "Byte", "Short", "UByte", "UShort":
+		return true
+	}
+	return false
+}